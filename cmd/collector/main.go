@@ -10,7 +10,29 @@ import (
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/defaultcomponents"
 
+	"github.com/hypertrace/collector/processors/bodyparserprocessor"
+	"github.com/hypertrace/collector/processors/brokentraceprocessor"
+	"github.com/hypertrace/collector/processors/clockskewprocessor"
+	"github.com/hypertrace/collector/processors/dedupprocessor"
+	"github.com/hypertrace/collector/processors/errorclassifierprocessor"
+	"github.com/hypertrace/collector/processors/geoipprocessor"
+	"github.com/hypertrace/collector/processors/graphqlprocessor"
+	"github.com/hypertrace/collector/processors/groupbytraceprocessor"
+	"github.com/hypertrace/collector/processors/headersprocessor"
+	"github.com/hypertrace/collector/processors/headsamplingprocessor"
+	"github.com/hypertrace/collector/processors/idrepairprocessor"
+	"github.com/hypertrace/collector/processors/ipanonymizationprocessor"
+	"github.com/hypertrace/collector/processors/normalizerprocessor"
+	"github.com/hypertrace/collector/processors/prioritylaneprocessor"
+	"github.com/hypertrace/collector/processors/sensitivedataprocessor"
+	"github.com/hypertrace/collector/processors/spanlimitsprocessor"
+	"github.com/hypertrace/collector/processors/tailsamplingprocessor"
+	"github.com/hypertrace/collector/processors/tenantadmissionprocessor"
 	"github.com/hypertrace/collector/processors/tenantidprocessor"
+	"github.com/hypertrace/collector/processors/tenantpolicyprocessor"
+	"github.com/hypertrace/collector/processors/tenantroutingprocessor"
+	"github.com/hypertrace/collector/processors/timestampvalidationprocessor"
+	"github.com/hypertrace/collector/processors/useragentprocessor"
 )
 
 func main() {
@@ -43,6 +65,28 @@ func components() (component.Factories, error) {
 
 	processors := []component.ProcessorFactory{
 		tenantidprocessor.NewFactory(),
+		tailsamplingprocessor.NewFactory(),
+		headsamplingprocessor.NewFactory(),
+		spanlimitsprocessor.NewFactory(),
+		clockskewprocessor.NewFactory(),
+		timestampvalidationprocessor.NewFactory(),
+		idrepairprocessor.NewFactory(),
+		dedupprocessor.NewFactory(),
+		groupbytraceprocessor.NewFactory(),
+		brokentraceprocessor.NewFactory(),
+		normalizerprocessor.NewFactory(),
+		errorclassifierprocessor.NewFactory(),
+		useragentprocessor.NewFactory(),
+		geoipprocessor.NewFactory(),
+		ipanonymizationprocessor.NewFactory(),
+		headersprocessor.NewFactory(),
+		bodyparserprocessor.NewFactory(),
+		graphqlprocessor.NewFactory(),
+		sensitivedataprocessor.NewFactory(),
+		tenantpolicyprocessor.NewFactory(),
+		tenantroutingprocessor.NewFactory(),
+		tenantadmissionprocessor.NewFactory(),
+		prioritylaneprocessor.NewFactory(),
 	}
 	for _, pr := range factories.Processors {
 		processors = append(processors, pr)
@@ -71,5 +115,27 @@ func run(params service.CollectorSettings) error {
 
 func registerMetricViews() error {
 	views := tenantidprocessor.MetricViews()
+	views = append(views, tailsamplingprocessor.MetricViews()...)
+	views = append(views, headsamplingprocessor.MetricViews()...)
+	views = append(views, spanlimitsprocessor.MetricViews()...)
+	views = append(views, clockskewprocessor.MetricViews()...)
+	views = append(views, timestampvalidationprocessor.MetricViews()...)
+	views = append(views, idrepairprocessor.MetricViews()...)
+	views = append(views, dedupprocessor.MetricViews()...)
+	views = append(views, groupbytraceprocessor.MetricViews()...)
+	views = append(views, brokentraceprocessor.MetricViews()...)
+	views = append(views, normalizerprocessor.MetricViews()...)
+	views = append(views, errorclassifierprocessor.MetricViews()...)
+	views = append(views, useragentprocessor.MetricViews()...)
+	views = append(views, geoipprocessor.MetricViews()...)
+	views = append(views, ipanonymizationprocessor.MetricViews()...)
+	views = append(views, headersprocessor.MetricViews()...)
+	views = append(views, bodyparserprocessor.MetricViews()...)
+	views = append(views, graphqlprocessor.MetricViews()...)
+	views = append(views, sensitivedataprocessor.MetricViews()...)
+	views = append(views, tenantpolicyprocessor.MetricViews()...)
+	views = append(views, tenantroutingprocessor.MetricViews()...)
+	views = append(views, tenantadmissionprocessor.MetricViews()...)
+	views = append(views, prioritylaneprocessor.MetricViews()...)
 	return view.Register(views...)
 }
@@ -10,6 +10,7 @@ require (
 	go.opentelemetry.io/collector v0.29.0
 	go.uber.org/zap v1.17.0
 	google.golang.org/grpc v1.38.0
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 // branch jaeger-thrift-http-headers
@@ -0,0 +1,61 @@
+// Package tracebatch contains helpers to regroup the spans of a batch by trace.
+package tracebatch
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// SplitByTrace copies the spans of td into one resource spans slice per trace ID.
+// Every span keeps its resource and instrumentation library.
+func SplitByTrace(td pdata.Traces) map[[16]byte]pdata.ResourceSpansSlice {
+	result := map[[16]byte]pdata.ResourceSpansSlice{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+
+		traceRSs := map[[16]byte]pdata.ResourceSpans{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+
+			traceILSs := map[[16]byte]pdata.InstrumentationLibrarySpans{}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				traceID := span.TraceID().Bytes()
+
+				traceILS, ok := traceILSs[traceID]
+				if !ok {
+					traceRS, ok := traceRSs[traceID]
+					if !ok {
+						batches, ok := result[traceID]
+						if !ok {
+							batches = pdata.NewResourceSpansSlice()
+							result[traceID] = batches
+						}
+						traceRS = batches.AppendEmpty()
+						rs.Resource().CopyTo(traceRS.Resource())
+						traceRSs[traceID] = traceRS
+					}
+					traceILS = traceRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(traceILS.InstrumentationLibrary())
+					traceILSs[traceID] = traceILS
+				}
+				span.CopyTo(traceILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return result
+}
+
+// SpanCount returns the number of spans in rss.
+func SpanCount(rss pdata.ResourceSpansSlice) int {
+	count := 0
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			count += ilss.At(j).Spans().Len()
+		}
+	}
+	return count
+}
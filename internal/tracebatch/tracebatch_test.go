@@ -0,0 +1,40 @@
+package tracebatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSplitByTrace(t *testing.T) {
+	td := pdata.NewTraces()
+	for _, service := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().SetName("lib-" + service)
+		for _, traceID := range []byte{1, 2, 1} {
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(pdata.NewTraceID([16]byte{traceID}))
+		}
+	}
+
+	split := SplitByTrace(td)
+	require.Equal(t, 2, len(split))
+
+	trace1 := split[[16]byte{1}]
+	require.Equal(t, 2, trace1.Len())
+	assert.Equal(t, 4, SpanCount(trace1))
+	for i, service := range []string{"a", "b"} {
+		rs := trace1.At(i)
+		name, _ := rs.Resource().Attributes().Get("service.name")
+		assert.Equal(t, service, name.StringVal())
+		require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+		assert.Equal(t, "lib-"+service, rs.InstrumentationLibrarySpans().At(0).InstrumentationLibrary().Name())
+	}
+
+	assert.Equal(t, 2, SpanCount(split[[16]byte{2}]))
+	assert.Equal(t, 6, td.SpanCount())
+}
@@ -0,0 +1,188 @@
+package bodyparserprocessor
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// capturedBody describes the attributes of a captured body.
+type capturedBody struct {
+	body           Body
+	key            string
+	contentTypeKey string
+	schemaKey      string
+}
+
+var capturedBodies = []capturedBody{
+	{
+		body:           RequestBody,
+		key:            "http.request.body",
+		contentTypeKey: "http.request.header.content-type",
+		schemaKey:      "http.request.body.schema",
+	},
+	{
+		body:           ResponseBody,
+		key:            "http.response.body",
+		contentTypeKey: "http.response.header.content-type",
+		schemaKey:      "http.response.body.schema",
+	},
+}
+
+type extraction struct {
+	path      []pathSegment
+	attribute string
+}
+
+type processor struct {
+	tenantIDAttributeKey string
+	maxBodySize          int
+	recordSchema         bool
+	extractions          map[Body][]extraction
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) (*processor, error) {
+	extractions := map[Body][]extraction{}
+	for _, e := range cfg.Extract {
+		path, err := parsePath(e.Path)
+		if err != nil {
+			return nil, err
+		}
+		body := e.Body
+		if body == "" {
+			body = RequestBody
+		}
+		extractions[body] = append(extractions[body], extraction{path: path, attribute: e.Attribute})
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		maxBodySize:          cfg.MaxBodySize,
+		recordSchema:         cfg.RecordSchema,
+		extractions:          extractions,
+	}, nil
+}
+
+// bodyCounts counts bodies per tenant and format or reason.
+type bodyCounts map[string]map[string]int64
+
+func (c bodyCounts) inc(tenantID, key string) {
+	if _, ok := c[tenantID]; !ok {
+		c[tenantID] = map[string]int64{}
+	}
+	c[tenantID][key]++
+}
+
+func (c bodyCounts) record(ctx context.Context, measure *stats.Int64Measure, key tag.Key) {
+	for tenantID, values := range c {
+		for value, count := range values {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(key, value))
+			stats.Record(tCtx, measure.M(count))
+		}
+	}
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	parsed := bodyCounts{}
+	skipped := bodyCounts{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				for _, b := range capturedBodies {
+					format, reason := p.processBody(span.Attributes(), b)
+					switch {
+					case format != "":
+						parsed.inc(tenantID, format)
+					case reason != "":
+						skipped.inc(tenantID, reason)
+					}
+				}
+			}
+		}
+	}
+
+	parsed.record(ctx, statParsedBodyCount, tagFormat)
+	skipped.record(ctx, statSkippedBodyCount, tagReason)
+
+	return traces, nil
+}
+
+// processBody parses the captured body and sets the schema and extracted attributes.
+// It returns the format of a parsed body or the reason the body was not parsed.
+// Both are empty when the body was not captured.
+func (p *processor) processBody(attrs pdata.AttributeMap, b capturedBody) (string, string) {
+	attr, ok := attrs.Get(b.key)
+	if !ok || attr.Type() != pdata.AttributeValueTypeString || attr.StringVal() == "" {
+		return "", ""
+	}
+	body := attr.StringVal()
+	if p.maxBodySize > 0 && len(body) > p.maxBodySize {
+		return "", reasonTooLarge
+	}
+
+	contentType := ""
+	if attr, ok := attrs.Get(b.contentTypeKey); ok {
+		contentType = attr.StringVal()
+	}
+	format := detectFormat(contentType, body)
+	if format == "" {
+		return "", reasonUnsupported
+	}
+	tree, err := parseBody(format, body)
+	if err != nil {
+		return "", reasonInvalid
+	}
+
+	if p.recordSchema {
+		attrs.UpsertString(b.schemaKey, schema(tree))
+	}
+	for _, e := range p.extractions[b.body] {
+		if v, ok := lookup(tree, e.path); ok {
+			upsertValue(attrs, e.attribute, v)
+		}
+	}
+	return format, ""
+}
+
+// upsertValue sets the attribute to a value of the parsed body. Objects and
+// lists are set as JSON, null values are ignored.
+func upsertValue(attrs pdata.AttributeMap, key string, v interface{}) {
+	switch v := v.(type) {
+	case string:
+		attrs.UpsertString(key, v)
+	case bool:
+		attrs.UpsertBool(key, v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			attrs.UpsertInt(key, i)
+		} else if f, err := v.Float64(); err == nil {
+			attrs.UpsertDouble(key, f)
+		} else {
+			attrs.UpsertString(key, v.String())
+		}
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		attrs.UpsertString(key, string(b))
+	}
+}
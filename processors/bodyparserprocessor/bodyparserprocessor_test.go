@@ -0,0 +1,122 @@
+package bodyparserprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func process(t *testing.T, cfg *Config, attrs map[string]string) pdata.AttributeMap {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, "jdoe")
+	for k, v := range attrs {
+		span.Attributes().InsertString(k, v)
+	}
+
+	p, err := newProcessor(cfg)
+	require.NoError(t, err)
+	td, err = p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+}
+
+func assertString(t *testing.T, attrs pdata.AttributeMap, key, expected string) {
+	attr, ok := attrs.Get(key)
+	require.True(t, ok, key)
+	assert.Equal(t, expected, attr.StringVal(), key)
+}
+
+func TestJSONBody(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Extract = []ExtractConfig{
+		{Path: "$.user.id", Attribute: "user.id"},
+		{Path: "$.user.admin", Attribute: "user.admin"},
+		{Path: "$.amount", Attribute: "amount"},
+		{Path: "$.tags", Attribute: "tags"},
+		{Path: "$.missing", Attribute: "missing"},
+		{Body: ResponseBody, Path: "$.items[1].name", Attribute: "item.name"},
+	}
+
+	attrs := process(t, cfg, map[string]string{
+		"http.request.header.content-type":  "application/json",
+		"http.request.body":                 `{"user":{"id":42,"admin":false},"amount":9.5,"tags":["a","b"]}`,
+		"http.response.header.content-type": "application/json",
+		"http.response.body":                `{"items":[{"name":"pen"},{"name":"ink"}]}`,
+	})
+
+	assertString(t, attrs, "http.request.body.schema", `{"amount":"number","tags":["string"],"user":{"admin":"boolean","id":"number"}}`)
+	assertString(t, attrs, "http.response.body.schema", `{"items":[{"name":"string"}]}`)
+
+	id, _ := attrs.Get("user.id")
+	assert.Equal(t, int64(42), id.IntVal())
+	admin, _ := attrs.Get("user.admin")
+	assert.Equal(t, pdata.AttributeValueTypeBool, admin.Type())
+	assert.False(t, admin.BoolVal())
+	amount, _ := attrs.Get("amount")
+	assert.Equal(t, 9.5, amount.DoubleVal())
+	assertString(t, attrs, "tags", `["a","b"]`)
+	assertString(t, attrs, "item.name", "ink")
+	_, ok := attrs.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestFormAndXMLBodies(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Extract = []ExtractConfig{
+		{Path: "user", Attribute: "user.name"},
+		{Body: ResponseBody, Path: "$.order.@id", Attribute: "order.id"},
+	}
+
+	attrs := process(t, cfg, map[string]string{
+		"http.request.header.content-type": "application/x-www-form-urlencoded",
+		"http.request.body":                "user=jdoe&remember=on",
+		"http.response.body":               `<order id="7"><total>12</total></order>`,
+	})
+
+	assertString(t, attrs, "http.request.body.schema", `{"remember":"string","user":"string"}`)
+	assertString(t, attrs, "http.response.body.schema", `{"order":{"@id":"string","total":"string"}}`)
+	assertString(t, attrs, "user.name", "jdoe")
+	assertString(t, attrs, "order.id", "7")
+}
+
+func TestSkippedBodies(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxBodySize = 16
+	cfg.Extract = []ExtractConfig{{Path: "$.id", Attribute: "id"}}
+
+	attrs := process(t, cfg, map[string]string{
+		"http.request.body":                 `{"id":"` + strings.Repeat("x", 16) + `"}`,
+		"http.response.header.content-type": "application/json",
+		"http.response.body":                `{"id":`,
+	})
+	for _, key := range []string{"http.request.body.schema", "http.response.body.schema", "id"} {
+		_, ok := attrs.Get(key)
+		assert.False(t, ok, key)
+	}
+
+	attrs = process(t, cfg, map[string]string{
+		"http.request.header.content-type": "text/html",
+		"http.request.body":                "<p>hi</p>",
+	})
+	_, ok := attrs.Get("http.request.body.schema")
+	assert.False(t, ok)
+	assertString(t, attrs, "http.request.body", "<p>hi</p>")
+}
+
+func TestRecordSchemaDisabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RecordSchema = false
+	cfg.Extract = []ExtractConfig{{Path: "$.id", Attribute: "id"}}
+
+	attrs := process(t, cfg, map[string]string{
+		"http.request.body": `{"id":"a1"}`,
+	})
+	_, ok := attrs.Get("http.request.body.schema")
+	assert.False(t, ok)
+	assertString(t, attrs, "id", "a1")
+}
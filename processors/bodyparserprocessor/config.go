@@ -0,0 +1,78 @@
+package bodyparserprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Body selects the captured body a value is extracted from.
+type Body string
+
+const (
+	// RequestBody is the http.request.body attribute.
+	RequestBody Body = "request"
+	// ResponseBody is the http.response.body attribute.
+	ResponseBody Body = "response"
+)
+
+// Config defines config for body parser processor.
+// The processor parses the http.request.body and http.response.body span
+// attributes captured by the agents. JSON, form encoded and XML bodies are
+// supported, the format is taken from the captured content-type header or
+// guessed from the body when the header is missing.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// MaxBodySize is the size in bytes of the largest body which is parsed,
+	// larger bodies are left as they are. Zero means no limit. Default 65536.
+	MaxBodySize int `mapstructure:"max_body_size"`
+	// RecordSchema sets the http.request.body.schema and http.response.body.schema
+	// attributes to the field names and types of the body. Default true.
+	RecordSchema bool `mapstructure:"record_schema"`
+	// Extract lists the body values copied into span attributes.
+	Extract []ExtractConfig `mapstructure:"extract"`
+}
+
+// ExtractConfig defines a body value copied into a span attribute.
+type ExtractConfig struct {
+	// Body is either request or response. Default request.
+	Body Body `mapstructure:"body"`
+	// Path selects the value, e.g. $.user.id or $.items[0].name. Form fields
+	// are selected by their name, XML elements by their name below the root
+	// element and XML attributes by their name prefixed with @.
+	Path string `mapstructure:"path"`
+	// Attribute is the span attribute key the value is copied to.
+	Attribute string `mapstructure:"attribute"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxBodySize < 0 {
+		return fmt.Errorf("max_body_size must not be negative, got %d", cfg.MaxBodySize)
+	}
+	for _, e := range cfg.Extract {
+		if err := e.validate(); err != nil {
+			return fmt.Errorf("invalid extraction of %q: %w", e.Path, err)
+		}
+	}
+	return nil
+}
+
+func (e ExtractConfig) validate() error {
+	switch e.Body {
+	case "", RequestBody, ResponseBody:
+	default:
+		return fmt.Errorf("unknown body %q", e.Body)
+	}
+	if e.Attribute == "" {
+		return errors.New("attribute is required")
+	}
+	_, err := parsePath(e.Path)
+	return err
+}
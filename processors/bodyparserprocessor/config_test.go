@@ -0,0 +1,50 @@
+package bodyparserprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	bCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, bCfg.TenantIDAttributeKey)
+	assert.Equal(t, 1024, bCfg.MaxBodySize)
+	assert.False(t, bCfg.RecordSchema)
+	assert.Equal(t, []ExtractConfig{
+		{Path: "$.user.id", Attribute: "user.id"},
+		{Body: ResponseBody, Path: "$.items[0].name", Attribute: "item.name"},
+	}, bCfg.Extract)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxBodySize = -1
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Extract = []ExtractConfig{{Path: "$.id"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Extract = []ExtractConfig{{Path: "$.items[x]", Attribute: "item"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Extract = []ExtractConfig{{Body: "query", Path: "$.id", Attribute: "id"}}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,53 @@
+package bodyparserprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_bodyparser"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultMaxBodySize          = 64 * 1024
+)
+
+// NewFactory creates a factory for the body parser processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		MaxBodySize:          defaultMaxBodySize,
+		RecordSchema:         true,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	p, err := newProcessor(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		p)
+}
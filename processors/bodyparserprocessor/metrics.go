@@ -0,0 +1,47 @@
+package bodyparserprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagFormat   = tag.MustNewKey("format")
+	tagReason   = tag.MustNewKey("reason")
+
+	statParsedBodyCount  = stats.Int64("body_parser_parsed_body_count", "Number of captured bodies parsed", stats.UnitDimensionless)
+	statSkippedBodyCount = stats.Int64("body_parser_skipped_body_count", "Number of captured bodies which were not parsed", stats.UnitDimensionless)
+)
+
+// Reasons a body is not parsed.
+const (
+	reasonTooLarge    = "too_large"
+	reasonUnsupported = "unsupported"
+	reasonInvalid     = "invalid"
+)
+
+// MetricViews returns the metrics views for body parser processor.
+func MetricViews() []*view.View {
+	viewParsedBodyCount := &view.View{
+		Name:        statParsedBodyCount.Name(),
+		Description: statParsedBodyCount.Description(),
+		Measure:     statParsedBodyCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagFormat},
+	}
+
+	viewSkippedBodyCount := &view.View{
+		Name:        statSkippedBodyCount.Name(),
+		Description: statSkippedBodyCount.Description(),
+		Measure:     statSkippedBodyCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagReason},
+	}
+
+	return []*view.View{
+		viewParsedBodyCount,
+		viewSkippedBodyCount,
+	}
+}
@@ -0,0 +1,177 @@
+package bodyparserprocessor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+)
+
+// Body formats.
+const (
+	formatJSON = "json"
+	formatForm = "form"
+	formatXML  = "xml"
+)
+
+// detectFormat returns the format of the body from its content type. Without a
+// content type the format is guessed from the body. It returns an empty string
+// for unsupported formats.
+func detectFormat(contentType, body string) string {
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return ""
+		}
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			return formatJSON
+		case mediaType == "application/x-www-form-urlencoded":
+			return formatForm
+		case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+			return formatXML
+		}
+		return ""
+	}
+
+	body = strings.TrimSpace(body)
+	switch {
+	case body == "":
+		return ""
+	case body[0] == '{' || body[0] == '[':
+		return formatJSON
+	case body[0] == '<':
+		return formatXML
+	case strings.Contains(body, "=") && !strings.ContainsAny(body, " \t\r\n"):
+		return formatForm
+	}
+	return ""
+}
+
+// parseBody parses the body into a tree of map[string]interface{}, []interface{},
+// string, json.Number, bool and nil values.
+func parseBody(format, body string) (interface{}, error) {
+	switch format {
+	case formatJSON:
+		return parseJSON(body)
+	case formatForm:
+		return parseForm(body)
+	case formatXML:
+		return parseXML(body)
+	}
+	return nil, errors.New("unsupported format " + format)
+}
+
+func parseJSON(body string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// parseForm returns the fields of the form, fields sent several times are lists.
+func parseForm(body string) (interface{}, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(body))
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(values))
+	for name, vs := range values {
+		if len(vs) == 1 {
+			fields[name] = vs[0]
+			continue
+		}
+		list := make([]interface{}, len(vs))
+		for i, v := range vs {
+			list[i] = v
+		}
+		fields[name] = list
+	}
+	return fields, nil
+}
+
+type xmlElement struct {
+	name   string
+	fields map[string]interface{}
+	text   strings.Builder
+}
+
+// value returns the text of an element without attributes and children,
+// otherwise its fields with the text stored as #text.
+func (e *xmlElement) value() interface{} {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.fields) == 0 {
+		return text
+	}
+	if text != "" {
+		e.fields["#text"] = text
+	}
+	return e.fields
+}
+
+// parseXML returns the root element keyed by its name. Attributes are stored
+// with an @ prefix and repeated child elements are lists.
+func parseXML(body string) (interface{}, error) {
+	dec := xml.NewDecoder(strings.NewReader(body))
+	var stack []*xmlElement
+	var root map[string]interface{}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != nil {
+				return nil, errors.New("multiple XML root elements")
+			}
+			e := &xmlElement{name: t.Name.Local, fields: map[string]interface{}{}}
+			for _, a := range t.Attr {
+				e.fields["@"+a.Name.Local] = a.Value
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = map[string]interface{}{e.name: e.value()}
+			} else {
+				addField(stack[len(stack)-1].fields, e.name, e.value())
+			}
+		}
+	}
+	if root == nil || len(stack) > 0 {
+		return nil, errors.New("missing XML root element")
+	}
+	return root, nil
+}
+
+func addField(fields map[string]interface{}, name string, value interface{}) {
+	existing, ok := fields[name]
+	if !ok {
+		fields[name] = value
+		return
+	}
+	if list, ok := existing.([]interface{}); ok {
+		fields[name] = append(list, value)
+		return
+	}
+	fields[name] = []interface{}{existing, value}
+}
@@ -0,0 +1,105 @@
+package bodyparserprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		format      string
+	}{
+		{"application/json; charset=utf-8", "", formatJSON},
+		{"application/vnd.api+json", "", formatJSON},
+		{"application/x-www-form-urlencoded", "", formatForm},
+		{"text/xml", "", formatXML},
+		{"application/soap+xml", "", formatXML},
+		{"text/plain", `{"a":1}`, ""},
+		{"", ` [1, 2]`, formatJSON},
+		{"", "<a/>", formatXML},
+		{"", "a=1&b=2", formatForm},
+		{"", "hello world", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.format, detectFormat(test.contentType, test.body), "%q %q", test.contentType, test.body)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	_, err := parseJSON(`{"a":1} {"b":2}`)
+	assert.Error(t, err)
+
+	_, err = parseJSON(`{"a":`)
+	assert.Error(t, err)
+
+	v, err := parseJSON(`{"a":[1,"x",true,null]}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":["number"]}`, schema(v))
+}
+
+func TestParseForm(t *testing.T) {
+	v, err := parseForm("name=jdoe&tag=a&tag=b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name": "jdoe",
+		"tag":  []interface{}{"a", "b"},
+	}, v)
+	assert.Equal(t, `{"name":"string","tag":["string"]}`, schema(v))
+
+	_, err = parseForm("a=%zz")
+	assert.Error(t, err)
+}
+
+func TestParseXML(t *testing.T) {
+	v, err := parseXML(`<?xml version="1.0"?>
+<order id="7">
+  <item sku="a1">Pen</item>
+  <item sku="b2">Ink</item>
+  <note>fragile</note>
+</order>`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"order": map[string]interface{}{
+			"@id": "7",
+			"item": []interface{}{
+				map[string]interface{}{"@sku": "a1", "#text": "Pen"},
+				map[string]interface{}{"@sku": "b2", "#text": "Ink"},
+			},
+			"note": "fragile",
+		},
+	}, v)
+
+	_, err = parseXML("<a><b></a>")
+	assert.Error(t, err)
+
+	_, err = parseXML("<a></a><b></b>")
+	assert.Error(t, err)
+
+	_, err = parseXML("<a>")
+	assert.Error(t, err)
+}
+
+func TestSchemaMergesListItems(t *testing.T) {
+	v, err := parseJSON(`{"items":[{"id":1},{"name":"x","id":2}],"empty":[],"none":null}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"empty":[],"items":[{"id":"number","name":"string"}],"none":"null"}`, schema(v))
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := parsePath("$.items[1].name")
+	require.NoError(t, err)
+	assert.Equal(t, []pathSegment{{field: "items"}, {index: 1, isIndex: true}, {field: "name"}}, path)
+
+	path, err = parsePath("user.id")
+	require.NoError(t, err)
+	assert.Equal(t, []pathSegment{{field: "user"}, {field: "id"}}, path)
+
+	for _, invalid := range []string{"", "$", "$.", "a..b", "a[", "a[-1]", "a[0]b"} {
+		_, err := parsePath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,78 @@
+package bodyparserprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment selects a field of an object or an item of a list.
+type pathSegment struct {
+	field string
+	index int
+	// isIndex is true when the segment selects a list item.
+	isIndex bool
+}
+
+// parsePath parses paths like $.items[0].name. The leading $ is optional.
+func parsePath(path string) ([]pathSegment, error) {
+	s := strings.TrimPrefix(path, "$")
+	var segments []pathSegment
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] in path %q", path)
+			}
+			index, err := strconv.Atoi(s[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q in path %q", s[i+1:i+end], path)
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			i += end + 1
+		case s[i] == '.' || i == 0:
+			if s[i] == '.' {
+				i++
+			}
+			end := strings.IndexAny(s[i:], ".[")
+			if end < 0 {
+				end = len(s) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty field name in path %q", path)
+			}
+			segments = append(segments, pathSegment{field: s[i : i+end]})
+			i += end
+		default:
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("path is required")
+	}
+	return segments, nil
+}
+
+// lookup returns the value selected by the path in the parsed body.
+func lookup(v interface{}, path []pathSegment) (interface{}, bool) {
+	for _, segment := range path {
+		if segment.isIndex {
+			list, ok := v.([]interface{})
+			if !ok || segment.index >= len(list) {
+				return nil, false
+			}
+			v = list[segment.index]
+			continue
+		}
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = fields[segment.field]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,69 @@
+package bodyparserprocessor
+
+import (
+	"encoding/json"
+)
+
+// Type names used in the schema.
+const (
+	typeString  = "string"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+	typeNull    = "null"
+)
+
+// schema returns the shape of the parsed body as JSON. Objects map field names
+// to their shape, lists hold the merged shape of their items and other values
+// are replaced by their type name, e.g. {"ids":["number"],"name":"string"}.
+// Field names are sorted, so bodies of the same shape have the same schema.
+func schema(v interface{}) string {
+	b, _ := json.Marshal(shape(v))
+	return string(b)
+}
+
+func shape(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for name, field := range v {
+			fields[name] = shape(field)
+		}
+		return fields
+	case []interface{}:
+		var item interface{}
+		for _, i := range v {
+			item = mergeShapes(item, shape(i))
+		}
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case string:
+		return typeString
+	case json.Number:
+		return typeNumber
+	case bool:
+		return typeBoolean
+	}
+	return typeNull
+}
+
+// mergeShapes combines the shapes of list items. Objects have the union of
+// their fields, otherwise the first shape which is not null wins.
+func mergeShapes(a, b interface{}) interface{} {
+	if a == nil || a == typeNull {
+		return b
+	}
+	if b == nil || b == typeNull {
+		return a
+	}
+	aFields, aOK := a.(map[string]interface{})
+	bFields, bOK := b.(map[string]interface{})
+	if !aOK || !bOK {
+		return a
+	}
+	for name, field := range bFields {
+		aFields[name] = mergeShapes(aFields[name], field)
+	}
+	return aFields
+}
@@ -0,0 +1,229 @@
+package brokentraceprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// tickInterval is the period in which tracked traces are checked for expiry.
+const tickInterval = time.Second
+
+// source identifies who reported a span.
+type source struct {
+	tenantID string
+	service  string
+}
+
+type spanInfo struct {
+	parentID [8]byte
+	source   source
+}
+
+type traceState struct {
+	arrival time.Time
+	spanIDs map[[8]byte]bool
+	spans   []spanInfo
+	hasRoot bool
+}
+
+// findings holds the counts per source of the checked traces.
+type findings struct {
+	orphanSpans       map[source]int64
+	rootlessTraces    map[source]int64
+	multiTenantTraces map[source]int64
+}
+
+func newFindings() findings {
+	return findings{
+		orphanSpans:       map[source]int64{},
+		rootlessTraces:    map[source]int64{},
+		multiTenantTraces: map[source]int64{},
+	}
+}
+
+type processor struct {
+	tenantIDAttributeKey string
+	window               time.Duration
+	numTraces            int
+
+	mu     sync.Mutex
+	traces map[[16]byte]*traceState
+	// order holds trace IDs in the order of their arrival.
+	order [][16]byte
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		window:               cfg.Window,
+		numTraces:            cfg.NumTraces,
+		traces:               map[[16]byte]*traceState{},
+		done:                 make(chan struct{}),
+	}
+}
+
+func (p *processor) start(context.Context, component.Host) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case now := <-ticker.C:
+				p.flush(now)
+			}
+		}
+	}()
+	return nil
+}
+
+// shutdown stops the periodic check. Traces still tracked are discarded
+// because they are likely incomplete.
+func (p *processor) shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	return nil
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	now := time.Now()
+	var evicted []*traceState
+
+	p.mu.Lock()
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		service, _ := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				p.track(span, source{tenantID: tenantID, service: service.StringVal()}, now)
+			}
+		}
+	}
+	for len(p.traces) > p.numTraces {
+		evicted = append(evicted, p.popOldest())
+	}
+	p.mu.Unlock()
+
+	if len(evicted) > 0 {
+		record(ctx, check(evicted))
+	}
+	return traces, nil
+}
+
+// track adds the span to the state of its trace. Must be called with mu held.
+func (p *processor) track(span pdata.Span, src source, now time.Time) {
+	traceID := span.TraceID().Bytes()
+	trace, ok := p.traces[traceID]
+	if !ok {
+		trace = &traceState{arrival: now, spanIDs: map[[8]byte]bool{}}
+		p.traces[traceID] = trace
+		p.order = append(p.order, traceID)
+	}
+
+	trace.spanIDs[span.SpanID().Bytes()] = true
+	trace.spans = append(trace.spans, spanInfo{parentID: span.ParentSpanID().Bytes(), source: src})
+	if span.ParentSpanID().IsEmpty() {
+		trace.hasRoot = true
+	}
+}
+
+// popOldest removes the oldest trace. Must be called with mu held.
+func (p *processor) popOldest() *traceState {
+	traceID := p.order[0]
+	p.order = p.order[1:]
+	trace := p.traces[traceID]
+	delete(p.traces, traceID)
+	return trace
+}
+
+// flush checks the traces tracked for longer than the window.
+func (p *processor) flush(now time.Time) {
+	var expired []*traceState
+	p.mu.Lock()
+	for len(p.order) > 0 && !p.traces[p.order[0]].arrival.Add(p.window).After(now) {
+		expired = append(expired, p.popOldest())
+	}
+	p.mu.Unlock()
+
+	if len(expired) > 0 {
+		record(context.Background(), check(expired))
+	}
+}
+
+// check counts orphan spans, rootless traces and multi-tenant traces.
+// A rootless trace is attributed to the sources of its orphan spans, these
+// are the services the trace appears to start at.
+func check(traces []*traceState) findings {
+	f := newFindings()
+	for _, trace := range traces {
+		sources := map[source]bool{}
+		orphanSources := map[source]bool{}
+		tenants := map[string]bool{}
+		for _, s := range trace.spans {
+			sources[s.source] = true
+			tenants[s.source.tenantID] = true
+			if s.parentID != [8]byte{} && !trace.spanIDs[s.parentID] {
+				f.orphanSpans[s.source]++
+				orphanSources[s.source] = true
+			}
+		}
+
+		if !trace.hasRoot {
+			if len(orphanSources) == 0 {
+				// Only possible with a cycle, e.g. spans being their own parent.
+				orphanSources = sources
+			}
+			for src := range orphanSources {
+				f.rootlessTraces[src]++
+			}
+		}
+		if len(tenants) > 1 {
+			for src := range sources {
+				f.multiTenantTraces[src]++
+			}
+		}
+	}
+	return f
+}
+
+func record(ctx context.Context, f findings) {
+	recordPerSource(ctx, statOrphanSpanCount, f.orphanSpans)
+	recordPerSource(ctx, statRootlessTraceCount, f.rootlessTraces)
+	recordPerSource(ctx, statMultiTenantTraceCount, f.multiTenantTraces)
+}
+
+func recordPerSource(ctx context.Context, measure *stats.Int64Measure, counts map[source]int64) {
+	for src, count := range counts {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, src.tenantID),
+			tag.Insert(tagService, src.service))
+		stats.Record(tCtx, measure.M(count))
+	}
+}
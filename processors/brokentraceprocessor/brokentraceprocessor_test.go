@@ -0,0 +1,104 @@
+package brokentraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+type testSpan struct {
+	traceID  byte
+	spanID   byte
+	parentID byte
+}
+
+func generateTraces(tenantID, service string, testSpans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, ts := range testSpans {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{ts.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{ts.spanID}))
+		if ts.parentID != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([8]byte{ts.parentID}))
+		}
+	}
+	return td
+}
+
+func popAll(p *processor) []*traceState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var traces []*traceState
+	for len(p.order) > 0 {
+		traces = append(traces, p.popOldest())
+	}
+	return traces
+}
+
+func TestCheck(t *testing.T) {
+	p := newProcessor(createDefaultConfig().(*Config))
+	frontend := source{tenantID: "jdoe", service: "frontend"}
+	backend := source{tenantID: "jdoe", service: "backend"}
+	partner := source{tenantID: "partner", service: "gateway"}
+
+	batches := []pdata.Traces{
+		// Trace 1 is complete.
+		generateTraces(frontend.tenantID, frontend.service, testSpan{traceID: 1, spanID: 1}),
+		generateTraces(backend.tenantID, backend.service, testSpan{traceID: 1, spanID: 2, parentID: 1}),
+		// Trace 2 misses the root, the backend span has no parent.
+		generateTraces(backend.tenantID, backend.service,
+			testSpan{traceID: 2, spanID: 2, parentID: 1},
+			testSpan{traceID: 2, spanID: 3, parentID: 2},
+			testSpan{traceID: 2, spanID: 4, parentID: 9}),
+		// Trace 3 is reported by two tenants.
+		generateTraces(frontend.tenantID, frontend.service, testSpan{traceID: 3, spanID: 1}),
+		generateTraces(partner.tenantID, partner.service, testSpan{traceID: 3, spanID: 2, parentID: 1}),
+	}
+	for _, td := range batches {
+		out, err := p.ProcessTraces(context.Background(), td)
+		require.NoError(t, err)
+		assert.Equal(t, td, out)
+	}
+
+	f := check(popAll(p))
+	assert.Equal(t, map[source]int64{backend: 2}, f.orphanSpans)
+	assert.Equal(t, map[source]int64{backend: 1}, f.rootlessTraces)
+	assert.Equal(t, map[source]int64{frontend: 1, partner: 1}, f.multiTenantTraces)
+}
+
+func TestSpansAcrossBatchesWithinWindow(t *testing.T) {
+	p := newProcessor(createDefaultConfig().(*Config))
+
+	_, err := p.ProcessTraces(context.Background(), generateTraces("jdoe", "backend", testSpan{traceID: 1, spanID: 2, parentID: 1}))
+	require.NoError(t, err)
+	p.flush(time.Now())
+	assert.Equal(t, 1, len(p.traces))
+
+	_, err = p.ProcessTraces(context.Background(), generateTraces("jdoe", "frontend", testSpan{traceID: 1, spanID: 1}))
+	require.NoError(t, err)
+	p.flush(time.Now().Add(defaultWindow))
+	assert.Empty(t, p.traces)
+	assert.Empty(t, p.order)
+}
+
+func TestCheckOldestWhenFull(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NumTraces = 1
+	p := newProcessor(cfg)
+
+	_, err := p.ProcessTraces(context.Background(), generateTraces("jdoe", "backend",
+		testSpan{traceID: 1, spanID: 2, parentID: 1},
+		testSpan{traceID: 2, spanID: 2, parentID: 1}))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(p.traces))
+	assert.Contains(t, p.traces, [16]byte{2})
+}
@@ -0,0 +1,39 @@
+package brokentraceprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for broken trace processor.
+// The processor does not modify spans. It keeps track of the span IDs of each
+// trace for Window and then reports spans whose parent never arrived, traces
+// without a root span and traces reported by more than one tenant.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Window is the time since the first span of a trace was received after
+	// which the trace is checked. Spans arriving later are tracked as a new trace,
+	// therefore the window should exceed the duration of most traces. Default 30s.
+	Window time.Duration `mapstructure:"window"`
+	// NumTraces is the maximum number of traces tracked. When the limit is
+	// reached the oldest trace is checked early. Default 100000.
+	NumTraces int `mapstructure:"num_traces"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", cfg.Window)
+	}
+	if cfg.NumTraces <= 0 {
+		return fmt.Errorf("num_traces must be positive, got %d", cfg.NumTraces)
+	}
+	return nil
+}
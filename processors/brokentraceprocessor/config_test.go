@@ -0,0 +1,39 @@
+package brokentraceprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	btCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", btCfg.TenantIDAttributeKey)
+	assert.Equal(t, time.Minute, btCfg.Window)
+	assert.Equal(t, 500, btCfg.NumTraces)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Window = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.NumTraces = -1
+	assert.Error(t, cfg.Validate())
+}
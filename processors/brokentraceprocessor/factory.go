@@ -0,0 +1,55 @@
+package brokentraceprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_brokentrace"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultWindow               = 30 * time.Second
+	defaultNumTraces            = 100000
+)
+
+// NewFactory creates a factory for the broken trace processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		Window:               defaultWindow,
+		NumTraces:            defaultNumTraces,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	p := newProcessor(cfg.(*Config))
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		p,
+		processorhelper.WithStart(p.start),
+		processorhelper.WithShutdown(p.shutdown),
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}))
+}
@@ -0,0 +1,34 @@
+package brokentraceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultWindow, cfg.Window)
+	assert.Equal(t, defaultNumTraces, cfg.NumTraces)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestCreateTraceProcessor(t *testing.T) {
+	factory := NewFactory()
+	tp, err := factory.CreateTracesProcessor(
+		context.Background(),
+		component.ProcessorCreateSettings{Logger: zap.NewNop()},
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.False(t, tp.Capabilities().MutatesData)
+	require.NoError(t, tp.Start(context.Background(), nil))
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
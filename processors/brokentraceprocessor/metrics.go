@@ -0,0 +1,51 @@
+package brokentraceprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagService  = tag.MustNewKey("service")
+
+	statOrphanSpanCount       = stats.Int64("broken_trace_orphan_span_count", "Number of spans whose parent was not received", stats.UnitDimensionless)
+	statRootlessTraceCount    = stats.Int64("broken_trace_rootless_trace_count", "Number of traces without a root span", stats.UnitDimensionless)
+	statMultiTenantTraceCount = stats.Int64("broken_trace_multi_tenant_trace_count", "Number of traces reported by more than one tenant", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for broken trace processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID, tagService}
+
+	viewOrphanSpanCount := &view.View{
+		Name:        statOrphanSpanCount.Name(),
+		Description: statOrphanSpanCount.Description(),
+		Measure:     statOrphanSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	viewRootlessTraceCount := &view.View{
+		Name:        statRootlessTraceCount.Name(),
+		Description: statRootlessTraceCount.Description(),
+		Measure:     statRootlessTraceCount,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	viewMultiTenantTraceCount := &view.View{
+		Name:        statMultiTenantTraceCount.Name(),
+		Description: statMultiTenantTraceCount.Description(),
+		Measure:     statMultiTenantTraceCount,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewOrphanSpanCount,
+		viewRootlessTraceCount,
+		viewMultiTenantTraceCount,
+	}
+}
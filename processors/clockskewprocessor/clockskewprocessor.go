@@ -0,0 +1,145 @@
+package clockskewprocessor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// adjustmentAttributeKey is set on shifted spans to the applied shift in nanoseconds.
+const adjustmentAttributeKey = "clock_skew.adjustment_ns"
+
+type processor struct {
+	tenantIDAttributeKey string
+	maxAdjustment        time.Duration
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+type spanNode struct {
+	span     pdata.Span
+	tenantID string
+	// host identifies the clock the span timestamps come from.
+	host     string
+	children []*spanNode
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	traceSpans := map[[16]byte]map[[8]byte]*spanNode{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		host := resourceKey(rs.Resource())
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				traceID := span.TraceID().Bytes()
+				if _, ok := traceSpans[traceID]; !ok {
+					traceSpans[traceID] = map[[8]byte]*spanNode{}
+				}
+				traceSpans[traceID][span.SpanID().Bytes()] = &spanNode{span: span, tenantID: tenantID, host: host}
+			}
+		}
+	}
+
+	adjusted := map[string]int64{}
+	for _, nodes := range traceSpans {
+		var roots []*spanNode
+		for _, n := range nodes {
+			parent, ok := nodes[n.span.ParentSpanID().Bytes()]
+			if n.span.ParentSpanID().IsEmpty() || !ok {
+				roots = append(roots, n)
+				continue
+			}
+			parent.children = append(parent.children, n)
+		}
+		for _, root := range roots {
+			p.adjust(root, 0, adjusted)
+		}
+	}
+
+	for tenantID, count := range adjusted {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statAdjustedSpanPerTenant.M(count))
+	}
+
+	return traces, nil
+}
+
+// adjust shifts the span of n by shift nanoseconds and corrects its children.
+// Children on the same host share the clock of the parent and inherit its shift,
+// children on other hosts starting before the parent are moved inside of it.
+func (p *processor) adjust(n *spanNode, shift int64, adjusted map[string]int64) {
+	if shift != 0 {
+		shiftSpan(n.span, shift)
+		adjusted[n.tenantID]++
+	}
+
+	parentStart := int64(n.span.StartTimestamp())
+	parentDuration := int64(n.span.EndTimestamp()) - parentStart
+	for _, c := range n.children {
+		childShift := int64(0)
+		if c.host == n.host {
+			childShift = shift
+		}
+
+		childStart := int64(c.span.StartTimestamp()) + childShift
+		if parentStart != 0 && c.span.StartTimestamp() != 0 && childStart < parentStart && c.host != n.host {
+			// Assume the network latency is equal in both directions and center the child in the parent.
+			childDuration := int64(c.span.EndTimestamp() - c.span.StartTimestamp())
+			target := parentStart
+			if childDuration <= parentDuration {
+				target += (parentDuration - childDuration) / 2
+			}
+			if correction := target - childStart; correction <= p.maxAdjustment.Nanoseconds() {
+				childShift += correction
+			}
+		}
+		p.adjust(c, childShift, adjusted)
+	}
+}
+
+func shiftSpan(span pdata.Span, shift int64) {
+	span.SetStartTimestamp(pdata.Timestamp(int64(span.StartTimestamp()) + shift))
+	span.SetEndTimestamp(pdata.Timestamp(int64(span.EndTimestamp()) + shift))
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		events.At(i).SetTimestamp(pdata.Timestamp(int64(events.At(i).Timestamp()) + shift))
+	}
+
+	total := shift
+	if prev, ok := span.Attributes().Get(adjustmentAttributeKey); ok {
+		total += prev.IntVal()
+	}
+	span.Attributes().UpsertInt(adjustmentAttributeKey, total)
+}
+
+// resourceKey returns a key identifying the resource by its attributes.
+func resourceKey(resource pdata.Resource) string {
+	var kvs []string
+	resource.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		kvs = append(kvs, k+"="+tracetranslator.AttributeValueToString(v))
+		return true
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, ",")
+}
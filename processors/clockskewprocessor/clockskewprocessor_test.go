@@ -0,0 +1,98 @@
+package clockskewprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var testTraceID = pdata.NewTraceID([16]byte{1})
+
+func addResourceSpans(td pdata.Traces, host string) pdata.SpanSlice {
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("host.name", host)
+	return rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+}
+
+func addSpan(spans pdata.SpanSlice, id, parentID byte, start, end int64) pdata.Span {
+	span := spans.AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(pdata.NewSpanID([8]byte{id}))
+	if parentID != 0 {
+		span.SetParentSpanID(pdata.NewSpanID([8]byte{parentID}))
+	}
+	span.SetStartTimestamp(pdata.Timestamp(start))
+	span.SetEndTimestamp(pdata.Timestamp(end))
+	return span
+}
+
+func newTestProcessor() *processor {
+	return &processor{
+		tenantIDAttributeKey: defaultTenantIDAttributeKey,
+		maxAdjustment:        time.Hour,
+	}
+}
+
+func TestSkewedSubtreeIsShifted(t *testing.T) {
+	td := pdata.NewTraces()
+	client := addSpan(addResourceSpans(td, "a"), 1, 0, 100, 200)
+	serverSpans := addResourceSpans(td, "b")
+	server := addSpan(serverSpans, 2, 1, 50, 80)
+	internal := addSpan(serverSpans, 3, 2, 55, 60)
+	internal.Events().AppendEmpty().SetTimestamp(57)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, pdata.Timestamp(100), client.StartTimestamp())
+	_, ok := client.Attributes().Get(adjustmentAttributeKey)
+	assert.False(t, ok)
+
+	assert.Equal(t, pdata.Timestamp(135), server.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(165), server.EndTimestamp())
+	adjustment, ok := server.Attributes().Get(adjustmentAttributeKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(85), adjustment.IntVal())
+
+	assert.Equal(t, pdata.Timestamp(140), internal.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(145), internal.EndTimestamp())
+	assert.Equal(t, pdata.Timestamp(142), internal.Events().At(0).Timestamp())
+}
+
+func TestConsistentTraceIsUnchanged(t *testing.T) {
+	td := pdata.NewTraces()
+	addSpan(addResourceSpans(td, "a"), 1, 0, 100, 200)
+	addSpan(addResourceSpans(td, "b"), 2, 1, 120, 180)
+	expected := td.Clone()
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
+
+func TestSameHostChildIsNotShifted(t *testing.T) {
+	td := pdata.NewTraces()
+	spans := addResourceSpans(td, "a")
+	addSpan(spans, 1, 0, 100, 200)
+	addSpan(spans, 2, 1, 50, 80)
+	expected := td.Clone()
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
+
+func TestLargeSkewIsNotShifted(t *testing.T) {
+	td := pdata.NewTraces()
+	addSpan(addResourceSpans(td, "a"), 1, 0, int64(2*time.Hour), int64(2*time.Hour+time.Second))
+	addSpan(addResourceSpans(td, "b"), 2, 1, 50, 80)
+	expected := td.Clone()
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
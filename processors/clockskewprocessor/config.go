@@ -0,0 +1,34 @@
+package clockskewprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for clock skew processor.
+// The processor moves child spans which start before their parent, together
+// with their subtree, inside the parent span. Only spans of the same trace
+// within a batch are compared, therefore the processor works best after the
+// group by trace processor (hypertrace_groupbytrace), which sends the spans of a
+// trace in a single batch.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// MaxAdjustment is the largest shift applied to a span. Larger skews are
+	// considered broken data and left untouched. Default 1h.
+	MaxAdjustment time.Duration `mapstructure:"max_adjustment"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxAdjustment <= 0 {
+		return fmt.Errorf("max_adjustment must be positive, got %s", cfg.MaxAdjustment)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package clockskewprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	csCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", csCfg.TenantIDAttributeKey)
+	assert.Equal(t, 5*time.Minute, csCfg.MaxAdjustment)
+}
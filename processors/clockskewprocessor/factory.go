@@ -0,0 +1,53 @@
+package clockskewprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_clockskew"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultMaxAdjustment        = time.Hour
+)
+
+// NewFactory creates a factory for the clock skew processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		MaxAdjustment:        defaultMaxAdjustment,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	pCfg := cfg.(*Config)
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		&processor{
+			tenantIDAttributeKey: pCfg.TenantIDAttributeKey,
+			maxAdjustment:        pCfg.MaxAdjustment,
+		})
+}
@@ -0,0 +1,14 @@
+package clockskewprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultMaxAdjustment, cfg.MaxAdjustment)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,30 @@
+package clockskewprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+
+	statAdjustedSpanPerTenant = stats.Int64("clock_skew_adjusted_span_count", "Number of spans from a tenant shifted to correct clock skew", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for clock skew processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID}
+
+	viewAdjustedSpanCount := &view.View{
+		Name:        statAdjustedSpanPerTenant.Name(),
+		Description: statAdjustedSpanPerTenant.Description(),
+		Measure:     statAdjustedSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewAdjustedSpanCount,
+	}
+}
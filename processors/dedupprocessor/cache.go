@@ -0,0 +1,87 @@
+package dedupprocessor
+
+import (
+	"container/list"
+	"time"
+)
+
+// entryOverhead approximates the memory used by a cache entry besides the tenant ID:
+// the key in the map and in the list element, the map bucket and the list element.
+const entryOverhead = 160
+
+type spanKey struct {
+	tenantID string
+	traceID  [16]byte
+	spanID   [8]byte
+}
+
+type cacheEntry struct {
+	key    spanKey
+	seenAt time.Time
+}
+
+// spanCache remembers spans for a time window within a memory budget.
+// It is not safe for concurrent use.
+type spanCache struct {
+	window   time.Duration
+	maxBytes int64
+	bytes    int64
+	entries  map[spanKey]*list.Element
+	// order holds entries from the oldest to the newest.
+	order *list.List
+}
+
+func newSpanCache(window time.Duration, maxBytes int64) *spanCache {
+	return &spanCache{
+		window:   window,
+		maxBytes: maxBytes,
+		entries:  map[spanKey]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// contains returns true if the key was added within the window.
+func (c *spanCache) contains(key spanKey, now time.Time) bool {
+	c.expire(now)
+	_, ok := c.entries[key]
+	return ok
+}
+
+// add adds the key if it is not in the cache and returns the number of
+// entries evicted because of the memory budget.
+func (c *spanCache) add(key spanKey, now time.Time) int {
+	c.expire(now)
+	if _, ok := c.entries[key]; ok {
+		return 0
+	}
+
+	c.entries[key] = c.order.PushBack(&cacheEntry{key: key, seenAt: now})
+	c.bytes += entrySize(key)
+
+	evicted := 0
+	for c.bytes > c.maxBytes && c.order.Len() > 0 {
+		c.remove(c.order.Front())
+		evicted++
+	}
+	return evicted
+}
+
+// expire removes entries older than the window.
+func (c *spanCache) expire(now time.Time) {
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		if now.Sub(e.Value.(*cacheEntry).seenAt) < c.window {
+			return
+		}
+		c.remove(e)
+	}
+}
+
+func (c *spanCache) remove(e *list.Element) {
+	entry := c.order.Remove(e).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entrySize(entry.key)
+}
+
+func entrySize(key spanKey) int64 {
+	return entryOverhead + int64(len(key.tenantID))
+}
@@ -0,0 +1,39 @@
+package dedupprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for deduplication processor.
+// The processor drops spans already received within a time window, e.g.
+// because a client or exporter retried a request. Spans are identified by
+// tenant ID, trace ID and span ID, therefore this processor has to run after
+// the tenant ID processor. Spans are only remembered once the next consumer
+// accepted them, so the retry of a failed request is not dropped.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Window is how long a span is remembered. Default 5m.
+	Window time.Duration `mapstructure:"window"`
+	// MemoryLimitMiB is the approximate memory used by the cache of seen spans.
+	// The oldest spans are forgotten when the limit is reached. Default 64.
+	MemoryLimitMiB int `mapstructure:"memory_limit_mib"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", cfg.Window)
+	}
+	if cfg.MemoryLimitMiB <= 0 {
+		return fmt.Errorf("memory_limit_mib must be positive, got %d", cfg.MemoryLimitMiB)
+	}
+	return nil
+}
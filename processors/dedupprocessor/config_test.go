@@ -0,0 +1,29 @@
+package dedupprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	dCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", dCfg.TenantIDAttributeKey)
+	assert.Equal(t, time.Minute, dCfg.Window)
+	assert.Equal(t, 16, dCfg.MemoryLimitMiB)
+}
@@ -0,0 +1,100 @@
+package dedupprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+	now                  func() time.Time
+	nextConsumer         consumer.Traces
+
+	mu    sync.Mutex
+	cache *spanCache
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+
+// Start implements component.Component
+func (p *processor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown implements component.Component
+func (p *processor) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements consumer.Traces
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// ConsumeTraces drops the spans already sent within the window and sends the
+// others to the next consumer. The spans are only remembered once the next
+// consumer accepted them, so that they are not dropped when the client
+// retries after a failure.
+func (p *processor) ConsumeTraces(ctx context.Context, traces pdata.Traces) error {
+	now := p.now()
+	duplicates := map[string]int64{}
+	var keys []spanKey
+	batch := map[spanKey]bool{}
+
+	p.mu.Lock()
+	traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				key := spanKey{tenantID: tenantID, traceID: span.TraceID().Bytes(), spanID: span.SpanID().Bytes()}
+				if batch[key] || p.cache.contains(key, now) {
+					duplicates[tenantID]++
+					return true
+				}
+				batch[key] = true
+				keys = append(keys, key)
+				return false
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	p.mu.Unlock()
+
+	for tenantID, count := range duplicates {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statDuplicateSpanPerTenant.M(count))
+	}
+
+	if traces.ResourceSpans().Len() == 0 {
+		// All the spans were already accepted.
+		return nil
+	}
+	if err := p.nextConsumer.ConsumeTraces(ctx, traces); err != nil {
+		return err
+	}
+
+	evicted := 0
+	p.mu.Lock()
+	for _, key := range keys {
+		evicted += p.cache.add(key, now)
+	}
+	p.mu.Unlock()
+	if evicted > 0 {
+		stats.Record(ctx, statCacheEvictions.M(int64(evicted)))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package dedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestProcessor(clock *testClock, maxBytes int64, next consumer.Traces) *processor {
+	return &processor{
+		tenantIDAttributeKey: defaultTenantIDAttributeKey,
+		cache:                newSpanCache(time.Minute, maxBytes),
+		now:                  clock.Now,
+		nextConsumer:         next,
+	}
+}
+
+// consume sends the traces through the processor and returns the span count received by the next consumer.
+func consume(t *testing.T, p *processor, td pdata.Traces) int {
+	sink := new(consumertest.TracesSink)
+	p.nextConsumer = sink
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	return sink.SpansCount()
+}
+
+func generateTraces(tenantID string, spanIDs ...byte) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, id := range spanIDs {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{1}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{id}))
+	}
+	return td
+}
+
+func TestDuplicatesAreDropped(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	p := newTestProcessor(clock, 1024*1024, nil)
+
+	assert.Equal(t, 2, consume(t, p, generateTraces("jdoe", 1, 2, 2)))
+	assert.Equal(t, 1, consume(t, p, generateTraces("jdoe", 2, 3)))
+	// A batch of duplicates is accepted without being sent.
+	assert.Equal(t, 0, consume(t, p, generateTraces("jdoe", 1, 2, 3)))
+}
+
+func TestSameIDsOfDifferentTenantsAreKept(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	p := newTestProcessor(clock, 1024*1024, nil)
+
+	assert.Equal(t, 1, consume(t, p, generateTraces("jdoe", 1)))
+	assert.Equal(t, 1, consume(t, p, generateTraces("jane", 1)))
+}
+
+func TestSpansAreForgottenAfterWindow(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	p := newTestProcessor(clock, 1024*1024, nil)
+
+	assert.Equal(t, 1, consume(t, p, generateTraces("jdoe", 1)))
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.Equal(t, 1, consume(t, p, generateTraces("jdoe", 1)))
+	assert.Equal(t, 1, p.cache.order.Len())
+}
+
+func TestMemoryLimitEvictsOldest(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	key := spanKey{tenantID: "jdoe"}
+	p := newTestProcessor(clock, 2*entrySize(key), nil)
+
+	assert.Equal(t, 3, consume(t, p, generateTraces("jdoe", 1, 2, 3)))
+	assert.Equal(t, 2, p.cache.order.Len())
+	assert.Equal(t, 2*entrySize(key), p.cache.bytes)
+
+	// The first span was evicted and is not recognized as duplicate anymore.
+	assert.Equal(t, 1, consume(t, p, generateTraces("jdoe", 1)))
+}
+
+func TestRetryAfterFailureIsAccepted(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	p := newTestProcessor(clock, 1024*1024, consumertest.NewErr(errors.New("export failed")))
+
+	assert.Error(t, p.ConsumeTraces(context.Background(), generateTraces("jdoe", 1, 2)))
+	assert.Zero(t, p.cache.order.Len())
+
+	// The retry of the failed batch is sent again.
+	assert.Equal(t, 2, consume(t, p, generateTraces("jdoe", 1, 2)))
+	assert.Equal(t, 0, consume(t, p, generateTraces("jdoe", 1, 2)))
+}
@@ -0,0 +1,54 @@
+package dedupprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_dedup"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultWindow               = 5 * time.Minute
+	defaultMemoryLimitMiB       = 64
+)
+
+// NewFactory creates a factory for the deduplication processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		Window:               defaultWindow,
+		MemoryLimitMiB:       defaultMemoryLimitMiB,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	pCfg := cfg.(*Config)
+	return &processor{
+		tenantIDAttributeKey: pCfg.TenantIDAttributeKey,
+		cache:                newSpanCache(pCfg.Window, int64(pCfg.MemoryLimitMiB)*1024*1024),
+		now:                  time.Now,
+		nextConsumer:         nextConsumer,
+	}, nil
+}
@@ -0,0 +1,15 @@
+package dedupprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultWindow, cfg.Window)
+	assert.Equal(t, defaultMemoryLimitMiB, cfg.MemoryLimitMiB)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,37 @@
+package dedupprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+
+	statDuplicateSpanPerTenant = stats.Int64("dedup_duplicate_span_count", "Number of duplicate spans from a tenant that were dropped", stats.UnitDimensionless)
+	statCacheEvictions         = stats.Int64("dedup_cache_eviction_count", "Number of spans forgotten before the end of the window because of the memory limit", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for deduplication processor.
+func MetricViews() []*view.View {
+	viewDuplicateSpanCount := &view.View{
+		Name:        statDuplicateSpanPerTenant.Name(),
+		Description: statDuplicateSpanPerTenant.Description(),
+		Measure:     statDuplicateSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	viewCacheEvictions := &view.View{
+		Name:        statCacheEvictions.Name(),
+		Description: statCacheEvictions.Description(),
+		Measure:     statCacheEvictions,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		viewDuplicateSpanCount,
+		viewCacheEvictions,
+	}
+}
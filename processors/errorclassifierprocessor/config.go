@@ -0,0 +1,61 @@
+package errorclassifierprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for error classifier processor.
+// The processor derives the span status and the error.type attribute from
+// exception events, gRPC and HTTP status codes and the Jaeger error tag, so
+// errors are reported the same way regardless of the receiver.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// DefaultRules apply to tenants without a dedicated entry in TenantRules.
+	DefaultRules RulesConfig `mapstructure:"default_rules"`
+	// TenantRules maps tenant ID to the rules applied to its spans.
+	TenantRules map[string]RulesConfig `mapstructure:"tenant_rules"`
+}
+
+// RulesConfig lists the signals which are not treated as errors.
+type RulesConfig struct {
+	// IgnoredHTTPStatusCodes are HTTP status codes which are not errors, e.g. 404.
+	IgnoredHTTPStatusCodes []int `mapstructure:"ignored_http_status_codes"`
+	// IgnoredGRPCStatusCodes are gRPC status codes which are not errors, e.g. 5 (NOT_FOUND).
+	IgnoredGRPCStatusCodes []int `mapstructure:"ignored_grpc_status_codes"`
+	// IgnoredExceptionTypes are exception types which are not errors.
+	IgnoredExceptionTypes []string `mapstructure:"ignored_exception_types"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.DefaultRules.validate(); err != nil {
+		return fmt.Errorf("invalid default rules: %w", err)
+	}
+	for tenantID, rules := range cfg.TenantRules {
+		if err := rules.validate(); err != nil {
+			return fmt.Errorf("invalid rules for tenant %q: %w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+func (r RulesConfig) validate() error {
+	for _, code := range r.IgnoredHTTPStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid HTTP status code %d", code)
+		}
+	}
+	for _, code := range r.IgnoredGRPCStatusCodes {
+		if _, ok := grpcStatusNames[code]; !ok {
+			return fmt.Errorf("invalid gRPC status code %d", code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package errorclassifierprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	ecCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", ecCfg.TenantIDAttributeKey)
+	assert.Equal(t, RulesConfig{IgnoredExceptionTypes: []string{"java.lang.InterruptedException"}}, ecCfg.DefaultRules)
+	assert.Equal(t, map[string]RulesConfig{
+		"jdoe": {
+			IgnoredHTTPStatusCodes: []int{404, 409},
+			IgnoredGRPCStatusCodes: []int{5},
+		},
+	}, ecCfg.TenantRules)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultRules.IgnoredHTTPStatusCodes = []int{4040}
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.TenantRules = map[string]RulesConfig{"jdoe": {IgnoredGRPCStatusCodes: []int{17}}}
+	assert.Error(t, cfg.Validate())
+}
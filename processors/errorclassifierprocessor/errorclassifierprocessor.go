@@ -0,0 +1,307 @@
+package errorclassifierprocessor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// errorTypeAttributeKey holds the class of the error of a span.
+	errorTypeAttributeKey = "error.type"
+	// errorTypeOther is used when the span status is error but there is no other error signal.
+	errorTypeOther = "_OTHER"
+
+	// Attributes holding the gRPC status code, the latter is used by Zipkin and OpenCensus instrumentations.
+	grpcStatusCodeAttributeKey       = "rpc.grpc.status_code"
+	legacyGRPCStatusCodeAttributeKey = "grpc.status_code"
+)
+
+// Sources of the error, ordered by precedence.
+const (
+	sourceException = "exception"
+	sourceGRPC      = "grpc"
+	sourceHTTP      = "http"
+	sourceTag       = "tag"
+	sourceStatus    = "status"
+)
+
+var grpcStatusNames = map[int]string{
+	0:  "OK",
+	1:  "CANCELLED",
+	2:  "UNKNOWN",
+	3:  "INVALID_ARGUMENT",
+	4:  "DEADLINE_EXCEEDED",
+	5:  "NOT_FOUND",
+	6:  "ALREADY_EXISTS",
+	7:  "PERMISSION_DENIED",
+	8:  "RESOURCE_EXHAUSTED",
+	9:  "FAILED_PRECONDITION",
+	10: "ABORTED",
+	11: "OUT_OF_RANGE",
+	12: "UNIMPLEMENTED",
+	13: "INTERNAL",
+	14: "UNAVAILABLE",
+	15: "DATA_LOSS",
+	16: "UNAUTHENTICATED",
+}
+
+type rules struct {
+	ignoredHTTPStatusCodes map[int64]bool
+	ignoredGRPCStatusCodes map[int64]bool
+	ignoredExceptionTypes  map[string]bool
+}
+
+func newRules(cfg RulesConfig) *rules {
+	r := &rules{
+		ignoredHTTPStatusCodes: map[int64]bool{},
+		ignoredGRPCStatusCodes: map[int64]bool{},
+		ignoredExceptionTypes:  map[string]bool{},
+	}
+	for _, code := range cfg.IgnoredHTTPStatusCodes {
+		r.ignoredHTTPStatusCodes[int64(code)] = true
+	}
+	for _, code := range cfg.IgnoredGRPCStatusCodes {
+		r.ignoredGRPCStatusCodes[int64(code)] = true
+	}
+	for _, t := range cfg.IgnoredExceptionTypes {
+		r.ignoredExceptionTypes[t] = true
+	}
+	return r
+}
+
+type processor struct {
+	tenantIDAttributeKey string
+	defaultRules         *rules
+	tenantRules          map[string]*rules
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	tenantRules := make(map[string]*rules, len(cfg.TenantRules))
+	for tenantID, r := range cfg.TenantRules {
+		tenantRules[tenantID] = newRules(r)
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		defaultRules:         newRules(cfg.DefaultRules),
+		tenantRules:          tenantRules,
+	}
+}
+
+// errorCounts counts error spans per tenant and source.
+type errorCounts map[string]map[string]int64
+
+func (c errorCounts) inc(tenantID, source string) {
+	if _, ok := c[tenantID]; !ok {
+		c[tenantID] = map[string]int64{}
+	}
+	c[tenantID][source]++
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	errorSpans := errorCounts{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				r, ok := p.tenantRules[tenantID]
+				if !ok {
+					r = p.defaultRules
+				}
+				if source := r.classify(span); source != "" {
+					errorSpans.inc(tenantID, source)
+				}
+			}
+		}
+	}
+
+	for tenantID, sources := range errorSpans {
+		for source, count := range sources {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagSource, source))
+			stats.Record(tCtx, statErrorSpanCount.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+// classify sets the status and the error.type attribute of the span. It returns
+// the source of the error or an empty string if the span is not an error.
+// When an error signal of a span is ignored by the tenant rules and no other
+// signal is an error, the span status is reset, since receivers derive the
+// status from the same signals.
+func (r *rules) classify(span pdata.Span) string {
+	ignored := false
+	// statusFound is true if the span has a gRPC or HTTP status code.
+	statusFound := false
+	source, errorType := "", ""
+
+	if t, _, isIgnored := r.exceptionError(span); t != "" {
+		source, errorType = sourceException, t
+	} else {
+		ignored = isIgnored
+	}
+	if source == "" {
+		t, found, isIgnored := r.grpcError(span.Attributes())
+		statusFound = statusFound || found
+		ignored = ignored || isIgnored
+		if t != "" {
+			source, errorType = sourceGRPC, t
+		}
+	}
+	if source == "" {
+		t, found, isIgnored := r.httpError(span)
+		statusFound = statusFound || found
+		ignored = ignored || isIgnored
+		if t != "" {
+			source, errorType = sourceHTTP, t
+		}
+	}
+	// Instrumentations set the error tag from the status code, so the tag is
+	// only used when the span has no status code.
+	if source == "" && !statusFound && errorTag(span.Attributes()) {
+		source, errorType = sourceTag, tracetranslator.TagError
+	}
+	if source == "" && !ignored && span.Status().Code() == pdata.StatusCodeError {
+		source, errorType = sourceStatus, errorTypeOther
+	}
+
+	status := span.Status()
+	if source == "" {
+		if ignored && status.Code() == pdata.StatusCodeError {
+			status.SetCode(pdata.StatusCodeUnset)
+			status.SetMessage("")
+		}
+		span.Attributes().Delete(errorTypeAttributeKey)
+		return ""
+	}
+
+	status.SetCode(pdata.StatusCodeError)
+	if status.Message() == "" {
+		status.SetMessage(errorType)
+	}
+	span.Attributes().UpsertString(errorTypeAttributeKey, errorType)
+	return source
+}
+
+// exceptionError returns the type of the first recorded exception which is not ignored.
+// found is true if the span recorded an exception, ignored is true if a recorded
+// exception type is ignored by the rules.
+func (r *rules) exceptionError(span pdata.Span) (errorType string, found bool, ignored bool) {
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() != conventions.AttributeExceptionEventName {
+			continue
+		}
+		found = true
+		t := conventions.AttributeExceptionEventName
+		if attr, ok := event.Attributes().Get(conventions.AttributeExceptionType); ok && attr.StringVal() != "" {
+			t = attr.StringVal()
+		}
+		if !r.ignoredExceptionTypes[t] {
+			return t, true, false
+		}
+		ignored = true
+	}
+	return "", found, ignored
+}
+
+// grpcError returns the name of a non OK gRPC status code. found is true if the
+// span has a gRPC status code, ignored is true if the code is ignored by the rules.
+func (r *rules) grpcError(attrs pdata.AttributeMap) (errorType string, found bool, ignored bool) {
+	code, ok := intAttribute(attrs, grpcStatusCodeAttributeKey)
+	if !ok {
+		code, ok = intAttribute(attrs, legacyGRPCStatusCodeAttributeKey)
+	}
+	if !ok {
+		return "", false, false
+	}
+	if r.ignoredGRPCStatusCodes[code] {
+		return "", true, true
+	}
+	if code == 0 {
+		return "", true, false
+	}
+	name, ok := grpcStatusNames[int(code)]
+	if !ok {
+		name = strconv.FormatInt(code, 10)
+	}
+	return name, true, false
+}
+
+// httpError follows the semantic conventions: 5xx codes are errors,
+// 4xx codes are errors for clients only. found is true if the span has an
+// HTTP status code, ignored is true if the code is ignored by the rules.
+func (r *rules) httpError(span pdata.Span) (errorType string, found bool, ignored bool) {
+	code, ok := intAttribute(span.Attributes(), conventions.AttributeHTTPStatusCode)
+	if !ok {
+		return "", false, false
+	}
+	threshold := int64(500)
+	if span.Kind() == pdata.SpanKindClient {
+		threshold = 400
+	}
+	if r.ignoredHTTPStatusCodes[code] {
+		return "", true, true
+	}
+	if code < threshold {
+		return "", true, false
+	}
+	return strconv.FormatInt(code, 10), true, false
+}
+
+// errorTag returns true if the span has the error tag set by Jaeger and Zipkin instrumentations.
+// Zipkin puts the error message into the tag.
+func errorTag(attrs pdata.AttributeMap) bool {
+	attr, ok := attrs.Get(tracetranslator.TagError)
+	if !ok {
+		return false
+	}
+	switch attr.Type() {
+	case pdata.AttributeValueTypeBool:
+		return attr.BoolVal()
+	case pdata.AttributeValueTypeString:
+		return attr.StringVal() != "" && !strings.EqualFold(attr.StringVal(), "false")
+	}
+	return false
+}
+
+func intAttribute(attrs pdata.AttributeMap, key string) (int64, bool) {
+	attr, ok := attrs.Get(key)
+	if !ok {
+		return 0, false
+	}
+	switch attr.Type() {
+	case pdata.AttributeValueTypeInt:
+		return attr.IntVal(), true
+	case pdata.AttributeValueTypeString:
+		i, err := strconv.ParseInt(attr.StringVal(), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,166 @@
+package errorclassifierprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const ignoringTenantID = "jdoe"
+
+func newTestProcessor() *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantRules = map[string]RulesConfig{
+		ignoringTenantID: {
+			IgnoredHTTPStatusCodes: []int{404},
+			IgnoredGRPCStatusCodes: []int{5},
+			IgnoredExceptionTypes:  []string{"CancelledException"},
+		},
+	}
+	return newProcessor(cfg)
+}
+
+type testSpan struct {
+	kind       pdata.SpanKind
+	status     pdata.StatusCode
+	attributes map[string]pdata.AttributeValue
+	exceptions []string
+}
+
+func classify(t *testing.T, tenantID string, ts testSpan) pdata.Span {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetKind(ts.kind)
+	span.Status().SetCode(ts.status)
+	pdata.NewAttributeMap().InitFromMap(ts.attributes).CopyTo(span.Attributes())
+	for _, exceptionType := range ts.exceptions {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().InsertString("exception.type", exceptionType)
+	}
+
+	td, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name              string
+		tenantID          string
+		span              testSpan
+		expectedStatus    pdata.StatusCode
+		expectedErrorType string
+	}{
+		{
+			name:           "no error",
+			span:           testSpan{kind: pdata.SpanKindServer, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(200)}},
+			expectedStatus: pdata.StatusCodeUnset,
+		},
+		{
+			name:              "http server error",
+			span:              testSpan{kind: pdata.SpanKindServer, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(503)}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "503",
+		},
+		{
+			name:           "http 404 on server",
+			span:           testSpan{kind: pdata.SpanKindServer, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404)}},
+			expectedStatus: pdata.StatusCodeUnset,
+		},
+		{
+			name:              "http 404 on client",
+			span:              testSpan{kind: pdata.SpanKindClient, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("404")}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "404",
+		},
+		{
+			name:           "ignored http 404 on client",
+			tenantID:       ignoringTenantID,
+			span:           testSpan{kind: pdata.SpanKindClient, status: pdata.StatusCodeError, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404)}},
+			expectedStatus: pdata.StatusCodeUnset,
+		},
+		{
+			name:              "grpc",
+			span:              testSpan{kind: pdata.SpanKindClient, attributes: map[string]pdata.AttributeValue{"rpc.grpc.status_code": pdata.NewAttributeValueInt(14)}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "UNAVAILABLE",
+		},
+		{
+			name:           "ignored grpc",
+			tenantID:       ignoringTenantID,
+			span:           testSpan{kind: pdata.SpanKindClient, attributes: map[string]pdata.AttributeValue{"grpc.status_code": pdata.NewAttributeValueInt(5)}},
+			expectedStatus: pdata.StatusCodeUnset,
+		},
+		{
+			name: "exception wins over http",
+			span: testSpan{
+				kind:       pdata.SpanKindServer,
+				attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(500)},
+				exceptions: []string{"java.lang.NullPointerException"},
+			},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "java.lang.NullPointerException",
+		},
+		{
+			name:     "ignored exception falls back to http",
+			tenantID: ignoringTenantID,
+			span: testSpan{
+				kind:       pdata.SpanKindServer,
+				attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(500)},
+				exceptions: []string{"CancelledException"},
+			},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "500",
+		},
+		{
+			name:              "zipkin error tag",
+			span:              testSpan{attributes: map[string]pdata.AttributeValue{"error": pdata.NewAttributeValueString("connection refused")}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: "error",
+		},
+		{
+			name:     "ignored http 404 with jaeger error tag",
+			tenantID: ignoringTenantID,
+			span: testSpan{
+				kind:       pdata.SpanKindClient,
+				status:     pdata.StatusCodeError,
+				attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404), "error": pdata.NewAttributeValueBool(true)},
+			},
+			expectedStatus: pdata.StatusCodeUnset,
+		},
+		{
+			name:              "error status with http 200",
+			span:              testSpan{kind: pdata.SpanKindServer, status: pdata.StatusCodeError, attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(200)}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: errorTypeOther,
+		},
+		{
+			name:              "error status with grpc OK",
+			tenantID:          ignoringTenantID,
+			span:              testSpan{kind: pdata.SpanKindServer, status: pdata.StatusCodeError, attributes: map[string]pdata.AttributeValue{"rpc.grpc.status_code": pdata.NewAttributeValueInt(0)}},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: errorTypeOther,
+		},
+		{
+			name:              "jaeger error tag translated to status",
+			span:              testSpan{status: pdata.StatusCodeError},
+			expectedStatus:    pdata.StatusCodeError,
+			expectedErrorType: errorTypeOther,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := classify(t, tt.tenantID, tt.span)
+			assert.Equal(t, tt.expectedStatus, span.Status().Code())
+			errorType, ok := span.Attributes().Get(errorTypeAttributeKey)
+			assert.Equal(t, tt.expectedErrorType != "", ok)
+			assert.Equal(t, tt.expectedErrorType, errorType.StringVal())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package errorclassifierprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_errorclassifier"
+	defaultTenantIDAttributeKey = "tenant-id"
+)
+
+// NewFactory creates a factory for the error classifier processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		newProcessor(cfg.(*Config)))
+}
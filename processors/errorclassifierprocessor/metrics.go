@@ -0,0 +1,29 @@
+package errorclassifierprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagSource   = tag.MustNewKey("source")
+
+	statErrorSpanCount = stats.Int64("error_classifier_error_span_count", "Number of spans classified as error", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for error classifier processor.
+func MetricViews() []*view.View {
+	viewErrorSpanCount := &view.View{
+		Name:        statErrorSpanCount.Name(),
+		Description: statErrorSpanCount.Description(),
+		Measure:     statErrorSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagSource},
+	}
+
+	return []*view.View{
+		viewErrorSpanCount,
+	}
+}
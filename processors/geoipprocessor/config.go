@@ -0,0 +1,40 @@
+package geoipprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for geo IP processor.
+// The processor looks up the client IP of spans in MaxMind databases read from
+// the local file system and adds the location and the autonomous system of the
+// client as geo.* attributes.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// CityDatabasePath is the path of a GeoIP2 or GeoLite2 City database.
+	CityDatabasePath string `mapstructure:"city_database_path"`
+	// ASNDatabasePath is the path of a GeoLite2 ASN database.
+	ASNDatabasePath string `mapstructure:"asn_database_path"`
+	// ReloadInterval is the period in which the database files are checked for
+	// changes and reloaded, 0 disables reloading. Default 1m.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.CityDatabasePath == "" && cfg.ASNDatabasePath == "" {
+		return errors.New("at least one of city_database_path and asn_database_path is required")
+	}
+	if cfg.ReloadInterval < 0 {
+		return fmt.Errorf("reload_interval must not be negative, got %s", cfg.ReloadInterval)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package geoipprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	gCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, gCfg.TenantIDAttributeKey)
+	assert.Equal(t, "/etc/geoip/GeoLite2-City.mmdb", gCfg.CityDatabasePath)
+	assert.Equal(t, "/etc/geoip/GeoLite2-ASN.mmdb", gCfg.ASNDatabasePath)
+	assert.Equal(t, 5*time.Minute, gCfg.ReloadInterval)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+
+	cfg.ASNDatabasePath = "asn.mmdb"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ReloadInterval = -time.Second
+	assert.Error(t, cfg.Validate())
+}
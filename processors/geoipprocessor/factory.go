@@ -0,0 +1,52 @@
+package geoipprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_geoip"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultReloadInterval       = time.Minute
+)
+
+// NewFactory creates a factory for the geo IP processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		ReloadInterval:       defaultReloadInterval,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	p := newProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		p,
+		processorhelper.WithStart(p.start),
+		processorhelper.WithShutdown(p.shutdown))
+}
@@ -0,0 +1,34 @@
+package geoipprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultReloadInterval, cfg.ReloadInterval)
+	assert.Empty(t, cfg.CityDatabasePath)
+	assert.Empty(t, cfg.ASNDatabasePath)
+}
+
+func TestCreateTraceProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.CityDatabasePath = "testdata/missing.mmdb"
+	tp, err := factory.CreateTracesProcessor(
+		context.Background(),
+		component.ProcessorCreateSettings{Logger: zap.NewNop()},
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.Error(t, tp.Start(context.Background(), nil))
+}
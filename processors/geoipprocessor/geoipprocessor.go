@@ -0,0 +1,341 @@
+package geoipprocessor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+const (
+	// forwardedForAttributeKey holds the captured X-Forwarded-For request header.
+	forwardedForAttributeKey = "http.request.header.x-forwarded-for"
+
+	// Attributes added to spans with a known client IP.
+	countryISOCodeAttributeKey  = "geo.country.iso_code"
+	countryNameAttributeKey     = "geo.country.name"
+	regionISOCodeAttributeKey   = "geo.region.iso_code"
+	regionNameAttributeKey      = "geo.region.name"
+	cityNameAttributeKey        = "geo.city.name"
+	asnNumberAttributeKey       = "geo.asn.number"
+	asnOrganizationAttributeKey = "geo.asn.organization"
+
+	// namesLanguage is the language of the names read from the city database.
+	namesLanguage = "en"
+)
+
+type database struct {
+	name string
+	path string
+	// modTime and size of the loaded file, a change triggers a reload.
+	modTime time.Time
+	size    int64
+	reader  *mmdbReader
+}
+
+type processor struct {
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	reloadInterval       time.Duration
+
+	// mu guards the readers of the databases.
+	mu   sync.RWMutex
+	city *database
+	asn  *database
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config) *processor {
+	p := &processor{
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		reloadInterval:       cfg.ReloadInterval,
+		done:                 make(chan struct{}),
+	}
+	if cfg.CityDatabasePath != "" {
+		p.city = &database{name: "city", path: cfg.CityDatabasePath}
+	}
+	if cfg.ASNDatabasePath != "" {
+		p.asn = &database{name: "asn", path: cfg.ASNDatabasePath}
+	}
+	return p
+}
+
+func (p *processor) databases() []*database {
+	var dbs []*database
+	for _, db := range []*database{p.city, p.asn} {
+		if db != nil {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs
+}
+
+// start loads the databases and watches them for changes.
+func (p *processor) start(context.Context, component.Host) error {
+	for _, db := range p.databases() {
+		if err := p.load(db); err != nil {
+			return fmt.Errorf("failed to load %s database %q: %w", db.name, db.path, err)
+		}
+	}
+	if p.reloadInterval == 0 {
+		return nil
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.reloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.reload()
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *processor) shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	return nil
+}
+
+// reload loads the databases whose file changed. A database which fails to load
+// is logged and the previous version is kept.
+func (p *processor) reload() {
+	for _, db := range p.databases() {
+		info, err := os.Stat(db.path)
+		if err != nil {
+			p.logger.Warn("Failed to check geo IP database", zap.String("path", db.path), zap.Error(err))
+			continue
+		}
+		p.mu.RLock()
+		unchanged := info.ModTime().Equal(db.modTime) && info.Size() == db.size
+		p.mu.RUnlock()
+		if unchanged {
+			continue
+		}
+
+		result := resultSuccess
+		if err := p.load(db); err != nil {
+			result = resultFailure
+			p.logger.Error("Failed to reload geo IP database", zap.String("path", db.path), zap.Error(err))
+		} else {
+			p.logger.Info("Reloaded geo IP database", zap.String("path", db.path))
+		}
+		ctx, _ := tag.New(context.Background(),
+			tag.Insert(tagDatabase, db.name),
+			tag.Insert(tagResult, result))
+		stats.Record(ctx, statReloadCount.M(1))
+	}
+}
+
+func (p *processor) load(db *database) error {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return err
+	}
+	reader, err := openMMDB(db.path)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	db.reader, db.modTime, db.size = reader, info.ModTime(), info.Size()
+	return nil
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	lookups := map[string]map[string]int64{}
+
+	p.mu.RLock()
+	var city, asn *mmdbReader
+	if p.city != nil {
+		city = p.city.reader
+	}
+	if p.asn != nil {
+		asn = p.asn.reader
+	}
+	p.mu.RUnlock()
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				ip := clientIP(span.Attributes())
+				if ip == nil {
+					continue
+				}
+
+				found := false
+				if city != nil {
+					found = p.addCity(span.Attributes(), city, ip) || found
+				}
+				if asn != nil {
+					found = p.addASN(span.Attributes(), asn, ip) || found
+				}
+
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				result := resultNotFound
+				if found {
+					result = resultFound
+				}
+				if _, ok := lookups[tenantID]; !ok {
+					lookups[tenantID] = map[string]int64{}
+				}
+				lookups[tenantID][result]++
+			}
+		}
+	}
+
+	for tenantID, results := range lookups {
+		for result, count := range results {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagResult, result))
+			stats.Record(tCtx, statLookupCount.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+func (p *processor) addCity(attrs pdata.AttributeMap, reader *mmdbReader, ip net.IP) bool {
+	record, found, err := reader.lookup(ip)
+	if err != nil {
+		p.logger.Debug("Failed to look up client IP in city database", zap.Error(err))
+		return false
+	}
+	if !found {
+		return false
+	}
+
+	upsertString(attrs, countryISOCodeAttributeKey, field(record, "country", "iso_code"))
+	upsertString(attrs, countryNameAttributeKey, field(record, "country", "names", namesLanguage))
+	if subdivisions, ok := field(record, "subdivisions").([]interface{}); ok && len(subdivisions) > 0 {
+		upsertString(attrs, regionISOCodeAttributeKey, field(subdivisions[0], "iso_code"))
+		upsertString(attrs, regionNameAttributeKey, field(subdivisions[0], "names", namesLanguage))
+	}
+	upsertString(attrs, cityNameAttributeKey, field(record, "city", "names", namesLanguage))
+	return true
+}
+
+func (p *processor) addASN(attrs pdata.AttributeMap, reader *mmdbReader, ip net.IP) bool {
+	record, found, err := reader.lookup(ip)
+	if err != nil {
+		p.logger.Debug("Failed to look up client IP in ASN database", zap.Error(err))
+		return false
+	}
+	if !found {
+		return false
+	}
+
+	if number, ok := field(record, "autonomous_system_number").(uint64); ok {
+		attrs.UpsertInt(asnNumberAttributeKey, int64(number))
+	}
+	upsertString(attrs, asnOrganizationAttributeKey, field(record, "autonomous_system_organization"))
+	return true
+}
+
+// field returns the value at the path of nested maps, or nil if it does not exist.
+func field(record interface{}, path ...string) interface{} {
+	for _, key := range path {
+		m, ok := record.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		record = m[key]
+	}
+	return record
+}
+
+func upsertString(attrs pdata.AttributeMap, key string, value interface{}) {
+	if s, ok := value.(string); ok && s != "" {
+		attrs.UpsertString(key, s)
+	}
+}
+
+// clientIP returns the first public IP of http.client_ip, the X-Forwarded-For
+// header and net.peer.ip. The left-most forwarded address is the original client.
+func clientIP(attrs pdata.AttributeMap) net.IP {
+	var candidates []string
+	if attr, ok := attrs.Get(conventions.AttributeHTTPClientIP); ok {
+		candidates = append(candidates, attr.StringVal())
+	}
+	if attr, ok := attrs.Get(forwardedForAttributeKey); ok {
+		candidates = append(candidates, strings.Split(attr.StringVal(), ",")...)
+	}
+	if attr, ok := attrs.Get(conventions.AttributeNetPeerIP); ok {
+		candidates = append(candidates, attr.StringVal())
+	}
+
+	for _, candidate := range candidates {
+		if ip := net.ParseIP(strings.TrimSpace(candidate)); ip != nil && isPublic(ip) {
+			return ip
+		}
+	}
+	return nil
+}
+
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+func isPublic(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package geoipprocessor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func cityRecord(country, region, city string) map[string]interface{} {
+	return map[string]interface{}{
+		"country":      map[string]interface{}{"iso_code": country, "names": map[string]interface{}{"en": country + " name"}},
+		"subdivisions": []interface{}{map[string]interface{}{"iso_code": region, "names": map[string]interface{}{"en": region + " name"}}},
+		"city":         map[string]interface{}{"names": map[string]interface{}{"en": city}},
+	}
+}
+
+func writeDatabases(t *testing.T, dir string, city string) (string, string) {
+	cityPath := filepath.Join(dir, "city.mmdb")
+	asnPath := filepath.Join(dir, "asn.mmdb")
+	require.NoError(t, ioutil.WriteFile(cityPath, buildMMDB(t, 6, 28,
+		mmdbEntry{cidr: "81.2.69.0/24", record: cityRecord("GB", "ENG", city)},
+		mmdbEntry{cidr: "2001:db8::/32", record: cityRecord("US", "CA", "San Francisco")},
+	), 0600))
+	require.NoError(t, ioutil.WriteFile(asnPath, buildMMDB(t, 4, 24,
+		mmdbEntry{cidr: "81.2.64.0/18", record: map[string]interface{}{
+			"autonomous_system_number":       uint32(20712),
+			"autonomous_system_organization": "Andrews & Arnold Ltd",
+		}},
+	), 0600))
+	return cityPath, asnPath
+}
+
+func newTestProcessor(t *testing.T, cityPath, asnPath string) *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CityDatabasePath = cityPath
+	cfg.ASNDatabasePath = asnPath
+	cfg.ReloadInterval = 0
+	p := newProcessor(zap.NewNop(), cfg)
+	require.NoError(t, p.start(context.Background(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	})
+	return p
+}
+
+func process(t *testing.T, p *processor, attrs map[string]pdata.AttributeValue) pdata.AttributeMap {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	pdata.NewAttributeMap().InitFromMap(attrs).CopyTo(span.Attributes())
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+}
+
+func TestEnrich(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cityPath, asnPath := writeDatabases(t, dir, "London")
+	p := newTestProcessor(t, cityPath, asnPath)
+
+	attrs := process(t, p, map[string]pdata.AttributeValue{
+		"http.request.header.x-forwarded-for": pdata.NewAttributeValueString("10.0.0.1, 81.2.69.142, 172.16.0.4"),
+		"net.peer.ip":                         pdata.NewAttributeValueString("172.16.0.4"),
+	})
+	assert.Equal(t, map[string]interface{}{
+		"http.request.header.x-forwarded-for": "10.0.0.1, 81.2.69.142, 172.16.0.4",
+		"net.peer.ip":                         "172.16.0.4",
+		countryISOCodeAttributeKey:            "GB",
+		countryNameAttributeKey:               "GB name",
+		regionISOCodeAttributeKey:             "ENG",
+		regionNameAttributeKey:                "ENG name",
+		cityNameAttributeKey:                  "London",
+		asnNumberAttributeKey:                 int64(20712),
+		asnOrganizationAttributeKey:           "Andrews & Arnold Ltd",
+	}, attributesAsMap(attrs))
+
+	attrs = process(t, p, map[string]pdata.AttributeValue{
+		"http.client_ip": pdata.NewAttributeValueString("2001:db8::1"),
+	})
+	city, _ := attrs.Get(cityNameAttributeKey)
+	assert.Equal(t, "San Francisco", city.StringVal())
+	_, ok := attrs.Get(asnNumberAttributeKey)
+	assert.False(t, ok)
+
+	attrs = process(t, p, map[string]pdata.AttributeValue{
+		"net.peer.ip": pdata.NewAttributeValueString("127.0.0.1"),
+	})
+	assert.Equal(t, 1, attrs.Len())
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cityPath, asnPath := writeDatabases(t, dir, "London")
+	p := newTestProcessor(t, cityPath, asnPath)
+
+	writeDatabases(t, dir, "Londinium")
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(cityPath, future, future))
+	// A broken database keeps the previous version.
+	require.NoError(t, ioutil.WriteFile(asnPath, []byte("broken"), 0600))
+	p.reload()
+
+	attrs := process(t, p, map[string]pdata.AttributeValue{
+		"net.peer.ip": pdata.NewAttributeValueString("81.2.69.142"),
+	})
+	city, _ := attrs.Get(cityNameAttributeKey)
+	assert.Equal(t, "Londinium", city.StringVal())
+	asn, _ := attrs.Get(asnNumberAttributeKey)
+	assert.Equal(t, int64(20712), asn.IntVal())
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]interface{} {
+	result := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			result[k] = v.IntVal()
+		default:
+			result[k] = v.StringVal()
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,47 @@
+package geoipprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagResult   = tag.MustNewKey("result")
+	tagDatabase = tag.MustNewKey("database")
+
+	statLookupCount = stats.Int64("geoip_lookup_count", "Number of client IP lookups", stats.UnitDimensionless)
+	statReloadCount = stats.Int64("geoip_database_reload_count", "Number of database reloads", stats.UnitDimensionless)
+)
+
+const (
+	resultFound    = "found"
+	resultNotFound = "not_found"
+	resultSuccess  = "success"
+	resultFailure  = "failure"
+)
+
+// MetricViews returns the metrics views for geo IP processor.
+func MetricViews() []*view.View {
+	viewLookupCount := &view.View{
+		Name:        statLookupCount.Name(),
+		Description: statLookupCount.Description(),
+		Measure:     statLookupCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagResult},
+	}
+
+	viewReloadCount := &view.View{
+		Name:        statReloadCount.Name(),
+		Description: statReloadCount.Description(),
+		Measure:     statReloadCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagDatabase, tagResult},
+	}
+
+	return []*view.View{
+		viewLookupCount,
+		viewReloadCount,
+	}
+}
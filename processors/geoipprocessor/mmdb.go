@@ -0,0 +1,313 @@
+package geoipprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+)
+
+// The reader implements the MaxMind DB format as described in
+// https://maxmind.github.io/MaxMind-DB/. Only lookups are supported.
+
+var metadataStartMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// maxDataStructureDepth is the maximum nesting depth of maps and arrays in the data section.
+const maxDataStructureDepth = 512
+
+// dataSectionSeparatorSize is the number of zero bytes between the search tree and the data section.
+const dataSectionSeparatorSize = 16
+
+var errInvalidDatabase = errors.New("invalid MaxMind DB")
+
+type mmdbReader struct {
+	tree         []byte
+	data         decoder
+	nodeCount    uint32
+	recordSize   uint16
+	ipVersion    uint16
+	databaseType string
+	ipv4Start    uint32
+}
+
+func openMMDB(path string) (*mmdbReader, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newMMDBReader(buf)
+}
+
+func newMMDBReader(buf []byte) (*mmdbReader, error) {
+	metadataStart := bytes.LastIndex(buf, metadataStartMarker)
+	if metadataStart == -1 {
+		return nil, fmt.Errorf("%w: metadata not found", errInvalidDatabase)
+	}
+	metadata, _, err := decoder{buf: buf[metadataStart+len(metadataStartMarker):]}.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %v", errInvalidDatabase, err)
+	}
+	m, ok := metadata.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", errInvalidDatabase)
+	}
+
+	r := &mmdbReader{}
+	nodeCount, _ := m["node_count"].(uint64)
+	recordSize, _ := m["record_size"].(uint64)
+	ipVersion, _ := m["ip_version"].(uint64)
+	r.databaseType, _ = m["database_type"].(string)
+	if recordSize != 24 && recordSize != 28 && recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", errInvalidDatabase, recordSize)
+	}
+	if ipVersion != 4 && ipVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", errInvalidDatabase, ipVersion)
+	}
+	if nodeCount == 0 || nodeCount > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: invalid node count %d", errInvalidDatabase, nodeCount)
+	}
+	r.nodeCount, r.recordSize, r.ipVersion = uint32(nodeCount), uint16(recordSize), uint16(ipVersion)
+
+	// The node count and the record size are bounded, so the size does not overflow.
+	if nodeCount*recordSize/4+dataSectionSeparatorSize > uint64(metadataStart) {
+		return nil, fmt.Errorf("%w: search tree exceeds the file", errInvalidDatabase)
+	}
+	treeSize := int(nodeCount * recordSize / 4)
+	r.tree = buf[:treeSize]
+	r.data = decoder{buf: buf[treeSize+dataSectionSeparatorSize : metadataStart]}
+
+	if r.ipVersion == 6 {
+		// IPv4 addresses are stored as ::a.b.c.d, skip the 96 leading zero bits.
+		node := uint32(0)
+		for i := 0; i < 96 && node < r.nodeCount; i++ {
+			node = r.readNode(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+// lookup returns the record of the network containing ip, found is false if there is none.
+func (r *mmdbReader) lookup(ip net.IP) (record interface{}, found bool, err error) {
+	node := uint32(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		node = r.ipv4Start
+	} else if r.ipVersion == 4 {
+		return nil, false, nil
+	}
+
+	for i := 0; i < len(ip)*8 && node < r.nodeCount; i++ {
+		bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	switch {
+	case node == r.nodeCount:
+		return nil, false, nil
+	case node < r.nodeCount:
+		return nil, false, fmt.Errorf("%w: search tree deeper than the address", errInvalidDatabase)
+	}
+	offset := int(node-r.nodeCount) - dataSectionSeparatorSize
+	record, _, err = r.data.decode(offset)
+	return record, err == nil, err
+}
+
+func (r *mmdbReader) readNode(node uint32, bit byte) uint32 {
+	b := r.tree[int(node)*int(r.recordSize)/4:]
+	switch r.recordSize {
+	case 24:
+		b = b[int(bit)*3:]
+		return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	case 28:
+		if bit == 0 {
+			return uint32(b[3]&0xf0)<<20 | uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+		}
+		return uint32(b[3]&0x0f)<<24 | uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	default:
+		return binary.BigEndian.Uint32(b[int(bit)*4:])
+	}
+}
+
+// Data section field types.
+const (
+	typeExtended = 0
+	typePointer  = 1
+	typeString   = 2
+	typeDouble   = 3
+	typeBytes    = 4
+	typeUint16   = 5
+	typeUint32   = 6
+	typeMap      = 7
+	typeInt32    = 8
+	typeUint64   = 9
+	typeUint128  = 10
+	typeArray    = 11
+	typeBool     = 14
+	typeFloat    = 15
+)
+
+type decoder struct {
+	buf []byte
+}
+
+// decode returns the value at offset and the offset following it. Unsigned
+// integers up to 64 bits are returned as uint64, signed ones as int64.
+func (d decoder) decode(offset int) (interface{}, int, error) {
+	return d.decodeValue(offset, 0)
+}
+
+// decodeValue decodes the value at offset nested in depth maps and arrays.
+func (d decoder) decodeValue(offset, depth int) (interface{}, int, error) {
+	b, offset, err := d.read(offset, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	ctrl := b[0]
+	typ := int(ctrl >> 5)
+
+	if typ == typePointer {
+		ss := int(ctrl>>3) & 0x3
+		b, offset, err = d.read(offset, ss+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		p := uint(ctrl & 0x7)
+		switch ss {
+		case 0:
+			p = p<<8 | uint(b[0])
+		case 1:
+			p = (p<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+		case 2:
+			p = (p<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+		default:
+			p = uint(binary.BigEndian.Uint32(b))
+		}
+		// The format does not allow pointers to pointers, following them could loop forever.
+		target, _, err := d.read(int(p), 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		if int(target[0]>>5) == typePointer {
+			return nil, 0, fmt.Errorf("%w: pointer to pointer", errInvalidDatabase)
+		}
+		v, _, err := d.decodeValue(int(p), depth)
+		return v, offset, err
+	}
+
+	if typ == typeExtended {
+		if b, offset, err = d.read(offset, 1); err != nil {
+			return nil, 0, err
+		}
+		typ = 7 + int(b[0])
+	}
+
+	size := int(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if b, offset, err = d.read(offset, n); err != nil {
+			return nil, 0, err
+		}
+		switch n {
+		case 1:
+			size = 29 + int(b[0])
+		case 2:
+			size = 285 + (int(b[0])<<8 | int(b[1]))
+		default:
+			size = 65821 + (int(b[0])<<16 | int(b[1])<<8 | int(b[2]))
+		}
+	}
+
+	if typ == typeMap || typ == typeArray {
+		if depth >= maxDataStructureDepth {
+			return nil, 0, fmt.Errorf("%w: data nested deeper than %d", errInvalidDatabase, maxDataStructureDepth)
+		}
+		// Every value takes at least one byte.
+		if size > len(d.buf)-offset {
+			return nil, 0, fmt.Errorf("%w: unexpected end of data", errInvalidDatabase)
+		}
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]interface{}, size)
+		for i := 0; i < size; i++ {
+			var key, value interface{}
+			if key, offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", errInvalidDatabase)
+			}
+			if value, offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			m[k] = value
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]interface{}, size)
+		for i := range a {
+			if a[i], offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	if b, offset, err = d.read(offset, size); err != nil {
+		return nil, 0, err
+	}
+	switch typ {
+	case typeString:
+		return string(b), offset, nil
+	case typeBytes:
+		return append([]byte(nil), b...), offset, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double of size %d", errInvalidDatabase, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), offset, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float of size %d", errInvalidDatabase, size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), offset, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: integer of size %d", errInvalidDatabase, size)
+		}
+		return uintFromBytes(b), offset, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: int32 of size %d", errInvalidDatabase, size)
+		}
+		return int64(int32(uintFromBytes(b))), offset, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(b), offset, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unsupported data type %d", errInvalidDatabase, typ)
+}
+
+// read returns n bytes at offset and the offset following them.
+func (d decoder) read(offset, n int) ([]byte, int, error) {
+	if offset < 0 || n < 0 || offset+n > len(d.buf) {
+		return nil, 0, fmt.Errorf("%w: unexpected end of data", errInvalidDatabase)
+	}
+	return d.buf[offset : offset+n], offset + n, nil
+}
+
+func uintFromBytes(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
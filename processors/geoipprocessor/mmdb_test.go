@@ -0,0 +1,270 @@
+package geoipprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mmdbEntry struct {
+	cidr   string
+	record interface{}
+}
+
+// buildMMDB writes a MaxMind DB holding the entries. Networks must not overlap.
+func buildMMDB(t *testing.T, ipVersion, recordSize int, entries ...mmdbEntry) []byte {
+	const empty = -1
+	nodes := [][2]int{{empty, empty}}
+	var data []byte
+	var dataOffsets []int
+
+	for i, e := range entries {
+		_, network, err := net.ParseCIDR(e.cidr)
+		require.NoError(t, err)
+		ip := []byte(network.IP)
+		ones, _ := network.Mask.Size()
+		if ip4 := network.IP.To4(); ip4 != nil && ipVersion == 6 {
+			ip = append(make([]byte, 12), ip4...)
+			ones += 96
+		}
+
+		node := 0
+		for bit := 0; bit < ones; bit++ {
+			b := (ip[bit/8] >> (7 - uint(bit%8))) & 1
+			if bit == ones-1 {
+				nodes[node][b] = -2 - i
+				break
+			}
+			if nodes[node][b] == empty {
+				nodes = append(nodes, [2]int{empty, empty})
+				nodes[node][b] = len(nodes) - 1
+			}
+			node = nodes[node][b]
+		}
+
+		dataOffsets = append(dataOffsets, len(data))
+		data = append(data, encodeMMDB(e.record)...)
+	}
+
+	nodeCount := len(nodes)
+	value := func(record int) uint32 {
+		switch {
+		case record == empty:
+			return uint32(nodeCount)
+		case record < empty:
+			return uint32(nodeCount + dataSectionSeparatorSize + dataOffsets[-2-record])
+		}
+		return uint32(record)
+	}
+
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		left, right := value(n[0]), value(n[1])
+		switch recordSize {
+		case 24:
+			buf.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(right >> 16), byte(right >> 8), byte(right)})
+		case 28:
+			buf.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(left>>24)<<4 | byte(right>>24), byte(right >> 16), byte(right >> 8), byte(right)})
+		default:
+			var b [8]byte
+			binary.BigEndian.PutUint32(b[:4], left)
+			binary.BigEndian.PutUint32(b[4:], right)
+			buf.Write(b[:])
+		}
+	}
+	buf.Write(make([]byte, dataSectionSeparatorSize))
+	buf.Write(data)
+	buf.Write(metadataStartMarker)
+	buf.Write(encodeMMDB(map[string]interface{}{
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(recordSize),
+		"ip_version":                  uint16(ipVersion),
+		"database_type":               "Test",
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(1625097600),
+		"languages":                   []interface{}{"en"},
+		"description":                 map[string]interface{}{"en": "Test database"},
+	}))
+	return buf.Bytes()
+}
+
+func encodeMMDB(v interface{}) []byte {
+	var payload []byte
+	var typ, size int
+	switch v := v.(type) {
+	case string:
+		typ, size, payload = typeString, len(v), []byte(v)
+	case uint16:
+		typ, payload = typeUint16, trimLeadingZeros(uint64(v))
+		size = len(payload)
+	case uint32:
+		typ, payload = typeUint32, trimLeadingZeros(uint64(v))
+		size = len(payload)
+	case uint64:
+		typ, payload = typeUint64, trimLeadingZeros(v)
+		size = len(payload)
+	case bool:
+		typ = typeBool
+		if v {
+			size = 1
+		}
+	case []interface{}:
+		typ, size = typeArray, len(v)
+		for _, item := range v {
+			payload = append(payload, encodeMMDB(item)...)
+		}
+	case map[string]interface{}:
+		typ, size = typeMap, len(v)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			payload = append(payload, encodeMMDB(k)...)
+			payload = append(payload, encodeMMDB(v[k])...)
+		}
+	default:
+		panic("unsupported type")
+	}
+
+	var header []byte
+	ctrlType := typ
+	if typ > 7 {
+		ctrlType = typeExtended
+	}
+	switch {
+	case size < 29:
+		header = []byte{byte(ctrlType<<5 | size)}
+	case size < 285:
+		header = []byte{byte(ctrlType<<5 | 29), byte(size - 29)}
+	case size < 65821:
+		s := size - 285
+		header = []byte{byte(ctrlType<<5 | 30), byte(s >> 8), byte(s)}
+	default:
+		s := size - 65821
+		header = []byte{byte(ctrlType<<5 | 31), byte(s >> 16), byte(s >> 8), byte(s)}
+	}
+	if typ > 7 {
+		// The extended type follows the control byte, before the size bytes.
+		header = append([]byte{header[0], byte(typ - 7)}, header[1:]...)
+	}
+	return append(header, payload...)
+}
+
+func trimLeadingZeros(v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return bytes.TrimLeft(b[:], "\x00")
+}
+
+func TestMMDBLookup(t *testing.T) {
+	longName := strings.Repeat("x", 300)
+	for _, ipVersion := range []int{4, 6} {
+		for _, recordSize := range []int{24, 28, 32} {
+			buf := buildMMDB(t, ipVersion, recordSize,
+				mmdbEntry{cidr: "1.2.3.0/24", record: map[string]interface{}{"name": "a", "number": uint32(15169)}},
+				mmdbEntry{cidr: "8.8.0.0/16", record: map[string]interface{}{"name": longName, "list": []interface{}{true, uint64(1)}}},
+			)
+			r, err := newMMDBReader(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "Test", r.databaseType)
+
+			record, found, err := r.lookup(net.ParseIP("1.2.3.4"))
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, map[string]interface{}{"name": "a", "number": uint64(15169)}, record)
+
+			record, found, err = r.lookup(net.ParseIP("8.8.8.8"))
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, map[string]interface{}{"name": longName, "list": []interface{}{true, uint64(1)}}, record)
+
+			_, found, err = r.lookup(net.ParseIP("9.9.9.9"))
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			_, found, err = r.lookup(net.ParseIP("2001:db8::1"))
+			require.NoError(t, err)
+			assert.False(t, found)
+		}
+	}
+}
+
+func TestDecodePointer(t *testing.T) {
+	buf := []byte{
+		// offset 0: "en"
+		0x42, 'e', 'n',
+		// offset 3: map with one pair, the key is a pointer to offset 0
+		0xe1, 0x20, 0x00, 0x41, 'x',
+	}
+	v, next, err := decoder{buf: buf}.decode(3)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), next)
+	assert.Equal(t, map[string]interface{}{"en": "x"}, v)
+}
+
+func TestInvalidMMDB(t *testing.T) {
+	_, err := newMMDBReader([]byte("not a database"))
+	assert.Error(t, err)
+
+	buf := buildMMDB(t, 4, 24, mmdbEntry{cidr: "1.2.3.0/24", record: "a"})
+	_, err = newMMDBReader(buf[20:])
+	assert.Error(t, err)
+
+	_, _, err = decoder{buf: []byte{0x45, 'a'}}.decode(0)
+	assert.Error(t, err)
+
+	// Node counts which are zero, truncated to 32 bits or overflow the tree size.
+	metadataStart := bytes.LastIndex(buf, metadataStartMarker)
+	for _, nodeCount := range []uint64{0, 1 << 32, 1 << 58} {
+		invalid := append(append([]byte{}, buf[:metadataStart]...), metadataStartMarker...)
+		invalid = append(invalid, encodeMMDB(map[string]interface{}{
+			"node_count":  nodeCount,
+			"record_size": uint16(32),
+			"ip_version":  uint16(4),
+		})...)
+		_, err = newMMDBReader(invalid)
+		assert.True(t, errors.Is(err, errInvalidDatabase), "node count %d: %v", nodeCount, err)
+	}
+}
+
+func TestMalformedMMDBData(t *testing.T) {
+	tests := map[string][]byte{
+		// A pointer to itself.
+		"pointer loop": {0x20, 0x00},
+		// A pointer to a pointer.
+		"pointer to pointer": {0x20, 0x02, 0x20, 0x00},
+		// A map whose value is a pointer to the map.
+		"map loop": {0xe1, 0x41, 'a', 0x20, 0x00},
+		// Arrays nested deeper than the limit.
+		"nested arrays": bytes.Repeat([]byte{0x01, 0x04}, maxDataStructureDepth+1),
+		// A map larger than the data.
+		"map size": {0xfd, 0xff, 0xff, 0xff},
+	}
+	for name, buf := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := decoder{buf: buf}.decode(0)
+			assert.True(t, errors.Is(err, errInvalidDatabase), err)
+		})
+	}
+
+	// A database whose record is a pointer to itself.
+	buf := buildMMDB(t, 4, 24, mmdbEntry{cidr: "1.2.3.0/24", record: "a"})
+	// The data section only holds the record and ends at the metadata.
+	dataStart := bytes.LastIndex(buf, metadataStartMarker) - len(encodeMMDB("a"))
+	copy(buf[dataStart:], []byte{0x20, 0x00})
+	r, err := newMMDBReader(buf)
+	require.NoError(t, err)
+	_, found, err := r.lookup(net.ParseIP("1.2.3.4"))
+	assert.Error(t, err)
+	assert.False(t, found)
+}
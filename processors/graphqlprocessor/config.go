@@ -0,0 +1,39 @@
+package graphqlprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for GraphQL processor.
+// The processor parses the GraphQL requests of spans sent to the GraphQL
+// endpoints. The query is read from the captured http.request.body attribute,
+// either as JSON or as an application/graphql document, or from the query
+// parameters of GET requests. The operation type, name and top level fields
+// are set as graphql.operation.type, graphql.operation.name and
+// graphql.operation.fields attributes.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Paths are the URL paths of the GraphQL endpoints. Default /graphql.
+	Paths []string `mapstructure:"paths"`
+	// RenameSpan sets the span name to the operation type and name, e.g.
+	// "query GetUser". Anonymous operations are named by their top level fields.
+	RenameSpan bool `mapstructure:"rename_span"`
+	// MaxQuerySize is the maximum size in bytes of the request body or URL
+	// query parsed, larger requests are skipped. Default 64KiB.
+	MaxQuerySize int `mapstructure:"max_query_size"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxQuerySize <= 0 {
+		return fmt.Errorf("max_query_size must be greater than 0, got %d", cfg.MaxQuerySize)
+	}
+	return nil
+}
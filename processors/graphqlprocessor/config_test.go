@@ -0,0 +1,37 @@
+package graphqlprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	gCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, gCfg.TenantIDAttributeKey)
+	assert.Equal(t, []string{"/graphql", "/api/graphql"}, gCfg.Paths)
+	assert.True(t, gCfg.RenameSpan)
+	assert.Equal(t, 32768, gCfg.MaxQuerySize)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxQuerySize = 0
+	assert.Error(t, cfg.Validate())
+}
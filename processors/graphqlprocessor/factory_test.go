@@ -0,0 +1,32 @@
+package graphqlprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Empty(t, cfg.Paths)
+	assert.False(t, cfg.RenameSpan)
+	assert.Equal(t, defaultMaxQuerySize, cfg.MaxQuerySize)
+}
+
+func TestCreateTraceProcessor(t *testing.T) {
+	factory := NewFactory()
+	tp, err := factory.CreateTracesProcessor(
+		context.Background(),
+		component.ProcessorCreateSettings{Logger: zap.NewNop()},
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+}
@@ -0,0 +1,223 @@
+package graphqlprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/url"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	requestBodyAttributeKey = "http.request.body"
+	contentTypeAttributeKey = "http.request.header.content-type"
+
+	operationTypeAttributeKey   = "graphql.operation.type"
+	operationNameAttributeKey   = "graphql.operation.name"
+	operationFieldsAttributeKey = "graphql.operation.fields"
+)
+
+var defaultPaths = []string{"/graphql"}
+
+// errQueryTooLarge is returned for requests exceeding the maximum query size.
+var errQueryTooLarge = errors.New("query too large")
+
+type processor struct {
+	tenantIDAttributeKey string
+	paths                map[string]bool
+	renameSpan           bool
+	maxQuerySize         int
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	paths := cfg.Paths
+	if len(paths) == 0 {
+		paths = defaultPaths
+	}
+	pathSet := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		pathSet[trimPath(path)] = true
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		paths:                pathSet,
+		renameSpan:           cfg.RenameSpan,
+		maxQuerySize:         cfg.MaxQuerySize,
+	}
+}
+
+// request is the GraphQL request sent over HTTP.
+type request struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	operations := map[string]map[string]int64{}
+	invalid := map[string]int64{}
+	oversized := map[string]int64{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !p.paths[trimPath(spanPath(span.Attributes()))] {
+					continue
+				}
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				req, ok, err := graphQLRequest(span.Attributes(), p.maxQuerySize)
+				if !ok {
+					continue
+				}
+				if err == errQueryTooLarge {
+					oversized[tenantID]++
+					continue
+				}
+				var op operation
+				if err == nil {
+					op, err = parseOperation(req.Query, req.OperationName)
+				}
+				if err != nil {
+					invalid[tenantID]++
+					continue
+				}
+
+				p.setOperation(span, op)
+				if _, ok := operations[tenantID]; !ok {
+					operations[tenantID] = map[string]int64{}
+				}
+				operations[tenantID][op.typ]++
+			}
+		}
+	}
+
+	for tenantID, types := range operations {
+		for typ, count := range types {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagOperationType, typ))
+			stats.Record(tCtx, statOperationSpanCount.M(count))
+		}
+	}
+	for tenantID, count := range invalid {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statInvalidSpanCount.M(count))
+	}
+	for tenantID, count := range oversized {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statOversizedSpanCount.M(count))
+	}
+
+	return traces, nil
+}
+
+func (p *processor) setOperation(span pdata.Span, op operation) {
+	attrs := span.Attributes()
+	attrs.UpsertString(operationTypeAttributeKey, op.typ)
+	if op.name != "" {
+		attrs.UpsertString(operationNameAttributeKey, op.name)
+	}
+	attrs.UpsertString(operationFieldsAttributeKey, strings.Join(op.fields, ","))
+
+	if p.renameSpan {
+		name := op.name
+		if name == "" {
+			name = strings.Join(op.fields, ",")
+		}
+		span.SetName(op.typ + " " + name)
+	}
+}
+
+// spanPath returns the URL path of the HTTP span.
+func spanPath(attrs pdata.AttributeMap) string {
+	if attr, ok := attrs.Get(conventions.AttributeHTTPRoute); ok {
+		return attr.StringVal()
+	}
+	for _, key := range []string{conventions.AttributeHTTPTarget, conventions.AttributeHTTPURL} {
+		if attr, ok := attrs.Get(key); ok {
+			if u, err := url.Parse(attr.StringVal()); err == nil {
+				return u.Path
+			}
+		}
+	}
+	return ""
+}
+
+func trimPath(path string) string {
+	if len(path) > 1 {
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// graphQLRequest returns the GraphQL request of the span. The request is taken
+// from the captured body and from the URL query of GET requests. Batched
+// requests are represented by their first request. It returns false when the
+// span has no GraphQL request and errQueryTooLarge when the body or query is
+// larger than maxQuerySize.
+func graphQLRequest(attrs pdata.AttributeMap, maxQuerySize int) (request, bool, error) {
+	if attr, ok := attrs.Get(requestBodyAttributeKey); ok && strings.TrimSpace(attr.StringVal()) != "" {
+		body := strings.TrimSpace(attr.StringVal())
+		if len(body) > maxQuerySize {
+			return request{}, true, errQueryTooLarge
+		}
+		if contentType, ok := attrs.Get(contentTypeAttributeKey); ok {
+			if mediaType, _, _ := mime.ParseMediaType(contentType.StringVal()); mediaType == "application/graphql" {
+				return request{Query: body}, true, nil
+			}
+		}
+
+		var req request
+		var err error
+		if strings.HasPrefix(body, "[") {
+			var batch []request
+			if err = json.Unmarshal([]byte(body), &batch); err == nil && len(batch) > 0 {
+				req = batch[0]
+			}
+		} else {
+			err = json.Unmarshal([]byte(body), &req)
+		}
+		if err == nil && req.Query == "" {
+			err = errors.New("missing query")
+		}
+		return req, true, err
+	}
+
+	for _, key := range []string{conventions.AttributeHTTPTarget, conventions.AttributeHTTPURL} {
+		if attr, ok := attrs.Get(key); ok {
+			u, err := url.Parse(attr.StringVal())
+			if err != nil {
+				continue
+			}
+			if query := u.Query().Get("query"); query != "" {
+				if len(query) > maxQuerySize {
+					return request{}, true, errQueryTooLarge
+				}
+				return request{Query: query, OperationName: u.Query().Get("operationName")}, true, nil
+			}
+		}
+	}
+	return request{}, false, nil
+}
@@ -0,0 +1,119 @@
+package graphqlprocessor
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func process(t *testing.T, cfg *Config, attrs map[string]string) pdata.Span {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("POST /graphql")
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, "jdoe")
+	for k, v := range attrs {
+		span.Attributes().InsertString(k, v)
+	}
+
+	td, err := newProcessor(cfg).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+func attribute(span pdata.Span, key string) string {
+	attr, _ := span.Attributes().Get(key)
+	return attr.StringVal()
+}
+
+func TestJSONRequest(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	span := process(t, cfg, map[string]string{
+		"http.target":       "/graphql/",
+		"http.request.body": `{"query":"query A { a } mutation B { createUser { id } updateUser { id } }","operationName":"B","variables":{}}`,
+	})
+
+	assert.Equal(t, operationMutation, attribute(span, operationTypeAttributeKey))
+	assert.Equal(t, "B", attribute(span, operationNameAttributeKey))
+	assert.Equal(t, "createUser,updateUser", attribute(span, operationFieldsAttributeKey))
+	assert.Equal(t, "POST /graphql", span.Name())
+}
+
+func TestRenameSpan(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RenameSpan = true
+
+	span := process(t, cfg, map[string]string{
+		"http.route":                       "/graphql",
+		"http.request.header.content-type": "application/graphql",
+		"http.request.body":                "query GetUser { user { id } }",
+	})
+	assert.Equal(t, "query GetUser", span.Name())
+
+	span = process(t, cfg, map[string]string{
+		"http.route":        "/graphql",
+		"http.request.body": `[{"query":"{ user { id } orders { id } }"},{"query":"{ other }"}]`,
+	})
+	assert.Equal(t, "query user,orders", span.Name())
+	_, ok := span.Attributes().Get(operationNameAttributeKey)
+	assert.False(t, ok)
+}
+
+func TestGETRequest(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	span := process(t, cfg, map[string]string{
+		"http.url": "https://example.com/graphql?query=subscription%20OnEvent%20%7B%20event%20%7D",
+	})
+	assert.Equal(t, operationSubscription, attribute(span, operationTypeAttributeKey))
+	assert.Equal(t, "OnEvent", attribute(span, operationNameAttributeKey))
+	assert.Equal(t, "event", attribute(span, operationFieldsAttributeKey))
+}
+
+func TestIgnoredSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Paths = []string{"/api/graphql"}
+	cfg.RenameSpan = true
+
+	tests := []map[string]string{
+		// Not a configured path.
+		{"http.target": "/graphql", "http.request.body": `{"query":"{ a }"}`},
+		// Invalid documents.
+		{"http.target": "/api/graphql", "http.request.body": `{"query":"{ a "}`},
+		{"http.target": "/api/graphql", "http.request.body": `{"variables":{}}`},
+		{"http.target": "/api/graphql", "http.request.body": `not json`},
+		// No request.
+		{"http.target": "/api/graphql"},
+	}
+	for _, attrs := range tests {
+		span := process(t, cfg, attrs)
+		_, ok := span.Attributes().Get(operationTypeAttributeKey)
+		assert.False(t, ok, attrs)
+		assert.Equal(t, "POST /graphql", span.Name())
+	}
+}
+
+func TestMaxQuerySize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxQuerySize = 64
+	tests := []map[string]string{
+		{"http.target": "/graphql", "http.request.body": `{"query":"{ ` + strings.Repeat("a ", 64) + `}"}`},
+		{"http.target": "/graphql?query=" + url.QueryEscape("{ "+strings.Repeat("a ", 64)+"}")},
+		// The stack is not exhausted by oversized documents.
+		{"http.target": "/graphql", "http.request.body": "{" + strings.Repeat("...{", 2_000_000)},
+	}
+	for _, attrs := range tests {
+		span := process(t, cfg, attrs)
+		_, ok := span.Attributes().Get(operationTypeAttributeKey)
+		assert.False(t, ok)
+	}
+
+	span := process(t, cfg, map[string]string{
+		"http.target":       "/graphql",
+		"http.request.body": `{"query":"{ user { id } }"}`,
+	})
+	assert.Equal(t, "user", attribute(span, operationFieldsAttributeKey))
+}
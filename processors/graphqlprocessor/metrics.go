@@ -0,0 +1,49 @@
+package graphqlprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID      = tag.MustNewKey("tenant-id")
+	tagOperationType = tag.MustNewKey("operation-type")
+
+	statOperationSpanCount = stats.Int64("graphql_operation_span_count", "Number of spans with a parsed GraphQL operation", stats.UnitDimensionless)
+	statInvalidSpanCount   = stats.Int64("graphql_invalid_span_count", "Number of GraphQL spans whose request could not be parsed", stats.UnitDimensionless)
+	statOversizedSpanCount = stats.Int64("graphql_oversized_span_count", "Number of GraphQL spans whose request was skipped for exceeding the maximum query size", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for GraphQL processor.
+func MetricViews() []*view.View {
+	viewOperationSpanCount := &view.View{
+		Name:        statOperationSpanCount.Name(),
+		Description: statOperationSpanCount.Description(),
+		Measure:     statOperationSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagOperationType},
+	}
+
+	viewInvalidSpanCount := &view.View{
+		Name:        statInvalidSpanCount.Name(),
+		Description: statInvalidSpanCount.Description(),
+		Measure:     statInvalidSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	viewOversizedSpanCount := &view.View{
+		Name:        statOversizedSpanCount.Name(),
+		Description: statOversizedSpanCount.Description(),
+		Measure:     statOversizedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	return []*view.View{
+		viewOperationSpanCount,
+		viewInvalidSpanCount,
+		viewOversizedSpanCount,
+	}
+}
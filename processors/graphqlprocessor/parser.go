@@ -0,0 +1,418 @@
+package graphqlprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxSelectionDepth is the maximum nesting depth of the parsed selection sets
+// and fragments, deeper documents are rejected to bound the recursion.
+const maxSelectionDepth = 64
+
+// Operation types.
+const (
+	operationQuery        = "query"
+	operationMutation     = "mutation"
+	operationSubscription = "subscription"
+)
+
+// operation is an operation of a GraphQL document.
+type operation struct {
+	typ  string
+	name string
+	// fields are the names of the top level fields, fragments are resolved.
+	fields []string
+}
+
+type tokenKind int
+
+const (
+	tokenPunctuator tokenKind = iota
+	tokenName
+	// tokenValue is a string or number literal.
+	tokenValue
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// punctuators of GraphQL except the spread, which is three characters long.
+const punctuators = "!$&():=@[]{|}"
+
+// tokenize splits the GraphQL document into tokens, ignoring white space,
+// commas and comments.
+func tokenize(doc string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(doc); {
+		c := doc[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case strings.HasPrefix(doc[i:], "\ufeff"):
+			i += len("\ufeff")
+		case c == '#':
+			for i < len(doc) && doc[i] != '\n' && doc[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(doc[i:], "..."):
+			tokens = append(tokens, token{kind: tokenPunctuator, value: "..."})
+			i += 3
+		case c == '-' || isDigit(c):
+			start := i
+			for i++; i < len(doc) && (isNameChar(doc[i]) || doc[i] == '.' || doc[i] == '+' || doc[i] == '-'); i++ {
+			}
+			tokens = append(tokens, token{kind: tokenValue, value: doc[start:i]})
+		case strings.IndexByte(punctuators, c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunctuator, value: string(c)})
+			i++
+		case c == '"':
+			end, err := stringEnd(doc, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenValue, value: doc[i:end]})
+			i = end
+		case isNameStart(c):
+			start := i
+			for i++; i < len(doc) && isNameChar(doc[i]); i++ {
+			}
+			tokens = append(tokens, token{kind: tokenName, value: doc[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+// stringEnd returns the position after the string or block string starting at start.
+func stringEnd(doc string, start int) (int, error) {
+	if strings.HasPrefix(doc[start:], `"""`) {
+		for i := start + 3; i+3 <= len(doc); i++ {
+			if strings.HasPrefix(doc[i:], `\"""`) {
+				i += 3
+				continue
+			}
+			if strings.HasPrefix(doc[i:], `"""`) {
+				return i + 3, nil
+			}
+		}
+		return 0, errors.New("unterminated block string")
+	}
+	for i := start + 1; i < len(doc); i++ {
+		switch doc[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		case '\n', '\r':
+			return 0, errors.New("unterminated string")
+		}
+	}
+	return 0, errors.New("unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+// selection is a top level selection of an operation or fragment.
+type selection struct {
+	field string
+	// spread is the name of a fragment spread.
+	spread string
+	// inline holds the selections of an inline fragment.
+	inline []selection
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) isPunctuator(value string) bool {
+	t, ok := p.peek()
+	return ok && t.kind == tokenPunctuator && t.value == value
+}
+
+func (p *parser) isName() bool {
+	t, ok := p.peek()
+	return ok && t.kind == tokenName
+}
+
+func (p *parser) expectPunctuator(value string) error {
+	if !p.isPunctuator(value) {
+		return fmt.Errorf("expected %q", value)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) expectName() (string, error) {
+	if !p.isName() {
+		return "", errors.New("expected name")
+	}
+	p.pos++
+	return p.tokens[p.pos-1].value, nil
+}
+
+// skipBalanced skips the tokens from the open punctuator to the matching close punctuator.
+func (p *parser) skipBalanced(open, close string) error {
+	depth := 0
+	for ; p.pos < len(p.tokens); p.pos++ {
+		t := p.tokens[p.pos]
+		if t.kind != tokenPunctuator {
+			continue
+		}
+		switch t.value {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("missing %q", close)
+}
+
+func (p *parser) skipDirectives() error {
+	for p.isPunctuator("@") {
+		p.pos++
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.isPunctuator("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseSelectionSet parses the selections of a selection set, nested selection
+// sets are skipped. The depth is the nesting depth of inline fragments.
+func (p *parser) parseSelectionSet(depth int) ([]selection, error) {
+	if depth > maxSelectionDepth {
+		return nil, fmt.Errorf("selection sets nested deeper than %d", maxSelectionDepth)
+	}
+	if err := p.expectPunctuator("{"); err != nil {
+		return nil, err
+	}
+	// Not nil, an inline fragment is recognized by its selections.
+	selections := []selection{}
+	for !p.isPunctuator("}") {
+		if p.isPunctuator("...") {
+			p.pos++
+			if t, ok := p.peek(); ok && t.kind == tokenName && t.value != "on" {
+				p.pos++
+				if err := p.skipDirectives(); err != nil {
+					return nil, err
+				}
+				selections = append(selections, selection{spread: t.value})
+				continue
+			}
+			if p.isName() {
+				// Type condition of the inline fragment.
+				p.pos++
+				if _, err := p.expectName(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.skipDirectives(); err != nil {
+				return nil, err
+			}
+			inline, err := p.parseSelectionSet(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			selections = append(selections, selection{inline: inline})
+			continue
+		}
+
+		field, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if p.isPunctuator(":") {
+			// The alias is followed by the field name.
+			p.pos++
+			if field, err = p.expectName(); err != nil {
+				return nil, err
+			}
+		}
+		if p.isPunctuator("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		if p.isPunctuator("{") {
+			if err := p.skipBalanced("{", "}"); err != nil {
+				return nil, err
+			}
+		}
+		selections = append(selections, selection{field: field})
+	}
+	p.pos++
+	return selections, nil
+}
+
+type operationDefinition struct {
+	typ        string
+	name       string
+	selections []selection
+}
+
+// parseOperation returns the operation of the document selected by operationName.
+// The name may be empty when the document has a single operation.
+func parseOperation(doc, operationName string) (operation, error) {
+	tokens, err := tokenize(doc)
+	if err != nil {
+		return operation{}, err
+	}
+
+	p := &parser{tokens: tokens}
+	var operations []operationDefinition
+	fragments := map[string][]selection{}
+	for p.pos < len(p.tokens) {
+		if p.isPunctuator("{") {
+			selections, err := p.parseSelectionSet(1)
+			if err != nil {
+				return operation{}, err
+			}
+			operations = append(operations, operationDefinition{typ: operationQuery, selections: selections})
+			continue
+		}
+
+		keyword, err := p.expectName()
+		if err != nil {
+			return operation{}, err
+		}
+		switch keyword {
+		case operationQuery, operationMutation, operationSubscription:
+			def := operationDefinition{typ: keyword}
+			if p.isName() {
+				def.name, _ = p.expectName()
+			}
+			if p.isPunctuator("(") {
+				if err := p.skipBalanced("(", ")"); err != nil {
+					return operation{}, err
+				}
+			}
+			if err := p.skipDirectives(); err != nil {
+				return operation{}, err
+			}
+			if def.selections, err = p.parseSelectionSet(1); err != nil {
+				return operation{}, err
+			}
+			operations = append(operations, def)
+		case "fragment":
+			name, err := p.expectName()
+			if err != nil {
+				return operation{}, err
+			}
+			if on, err := p.expectName(); err != nil || on != "on" {
+				return operation{}, errors.New("expected type condition")
+			}
+			if _, err := p.expectName(); err != nil {
+				return operation{}, err
+			}
+			if err := p.skipDirectives(); err != nil {
+				return operation{}, err
+			}
+			if fragments[name], err = p.parseSelectionSet(1); err != nil {
+				return operation{}, err
+			}
+		default:
+			return operation{}, fmt.Errorf("unexpected definition %q", keyword)
+		}
+	}
+
+	def, err := selectOperation(operations, operationName)
+	if err != nil {
+		return operation{}, err
+	}
+	fields, err := resolveFields(def.selections, fragments, map[string]bool{}, nil, 1)
+	if err != nil {
+		return operation{}, err
+	}
+	return operation{
+		typ:    def.typ,
+		name:   def.name,
+		fields: fields,
+	}, nil
+}
+
+func selectOperation(operations []operationDefinition, name string) (operationDefinition, error) {
+	if name == "" {
+		if len(operations) != 1 {
+			return operationDefinition{}, fmt.Errorf("operation name is required for %d operations", len(operations))
+		}
+		return operations[0], nil
+	}
+	for _, op := range operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return operationDefinition{}, fmt.Errorf("unknown operation %q", name)
+}
+
+// resolveFields appends the field names of the selections to fields, skipping
+// duplicates. Fragment spreads are replaced by the fields of the fragment.
+// The depth is the nesting depth of fragment spreads and inline fragments.
+func resolveFields(selections []selection, fragments map[string][]selection, visited map[string]bool, fields []string, depth int) ([]string, error) {
+	if depth > maxSelectionDepth {
+		return nil, fmt.Errorf("fragments nested deeper than %d", maxSelectionDepth)
+	}
+	var err error
+	for _, s := range selections {
+		switch {
+		case s.spread != "":
+			if !visited[s.spread] {
+				visited[s.spread] = true
+				fields, err = resolveFields(fragments[s.spread], fragments, visited, fields, depth+1)
+			}
+		case s.inline != nil:
+			fields, err = resolveFields(s.inline, fragments, visited, fields, depth+1)
+		case !contains(fields, s.field):
+			fields = append(fields, s.field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package graphqlprocessor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOperation(t *testing.T) {
+	tests := []struct {
+		doc           string
+		operationName string
+		expected      operation
+	}{
+		{
+			doc:      `{ user(id: "1") { name } orders { id } }`,
+			expected: operation{typ: operationQuery, fields: []string{"user", "orders"}},
+		},
+		{
+			doc: `# fetch the user
+query GetUser($id: ID!, $size: Int = -1) @cached(ttl: 60) {
+  me: user(id: $id, filter: {name: "a } b"}) @include(if: true) { ...UserFields }
+  user { id }
+}
+fragment UserFields on User { id name }`,
+			expected: operation{typ: operationQuery, name: "GetUser", fields: []string{"user"}},
+		},
+		{
+			doc:      `mutation { createUser(input: {name: """multi "quoted" \""" line"""}) { id } }`,
+			expected: operation{typ: operationMutation, fields: []string{"createUser"}},
+		},
+		{
+			doc:           `query A { a } subscription B { onEvent { id } }`,
+			operationName: "B",
+			expected:      operation{typ: operationSubscription, name: "B", fields: []string{"onEvent"}},
+		},
+		{
+			doc: `query Search {
+  ...Top
+  ... on Query { search { id } }
+  ... @skip(if: false) { total }
+}
+fragment Top on Query { viewer ...Top }`,
+			expected: operation{typ: operationQuery, name: "Search", fields: []string{"viewer", "search", "total"}},
+		},
+	}
+	for _, test := range tests {
+		op, err := parseOperation(test.doc, test.operationName)
+		require.NoError(t, err, test.doc)
+		assert.Equal(t, test.expected, op, test.doc)
+	}
+}
+
+func TestParseOperationErrors(t *testing.T) {
+	tests := []struct {
+		doc           string
+		operationName string
+	}{
+		{doc: `query { user `},
+		{doc: `query { user(id: "1) }`},
+		{doc: `query A { a } query B { b }`},
+		{doc: `query A { a }`, operationName: "B"},
+		{doc: `type User { id: ID }`},
+		{doc: `fragment F User { id }`},
+		{doc: `query { a % b }`},
+		{doc: ``},
+	}
+	for _, test := range tests {
+		_, err := parseOperation(test.doc, test.operationName)
+		assert.Error(t, err, test.doc)
+	}
+}
+
+func TestParseOperationDepthLimit(t *testing.T) {
+	op, err := parseOperation("{"+strings.Repeat("... on A {", maxSelectionDepth-1)+"a"+strings.Repeat("}", maxSelectionDepth), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, op.fields)
+
+	_, err = parseOperation("{"+strings.Repeat("...{", 2_000_000), "")
+	assert.Error(t, err)
+	_, err = parseOperation("{"+strings.Repeat("...{", maxSelectionDepth)+"a"+strings.Repeat("}", maxSelectionDepth+1), "")
+	assert.Error(t, err)
+
+	// Fragments spreading the next fragment.
+	var doc strings.Builder
+	doc.WriteString("{ ...F0 }")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&doc, " fragment F%d on A { ...F%d }", i, i+1)
+	}
+	_, err = parseOperation(doc.String(), "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package groupbytraceprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// EvictionPolicy defines what happens to the oldest trace when the buffer is full.
+type EvictionPolicy string
+
+const (
+	// EvictEmit forwards the oldest trace even though it may be incomplete.
+	EvictEmit EvictionPolicy = "emit"
+	// EvictDrop discards the oldest trace.
+	EvictDrop EvictionPolicy = "drop"
+)
+
+// Config defines config for group by trace processor.
+// The processor buffers spans by trace ID and forwards each trace in a single
+// batch once it is considered complete, so processors later in the pipeline
+// see whole traces. The outgoing context does not carry the metadata of the
+// receiver, therefore this processor has to run after the tenant ID processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// WaitDuration is the time since the first span of a trace was received
+	// after which the trace is forwarded. Default 10s.
+	WaitDuration time.Duration `mapstructure:"wait_duration"`
+	// EmitOnRoot forwards a trace as soon as its root span, a span without
+	// a parent, is received. Default true.
+	EmitOnRoot bool `mapstructure:"emit_on_root"`
+	// NumTraces is the maximum number of traces kept in memory. Default 100000.
+	NumTraces int `mapstructure:"num_traces"`
+	// EvictionPolicy defines what is done with the oldest trace when NumTraces
+	// is exceeded, either emit or drop. Default emit.
+	EvictionPolicy EvictionPolicy `mapstructure:"eviction_policy"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.WaitDuration <= 0 {
+		return fmt.Errorf("wait_duration must be positive, got %s", cfg.WaitDuration)
+	}
+	if cfg.NumTraces <= 0 {
+		return fmt.Errorf("num_traces must be positive, got %d", cfg.NumTraces)
+	}
+	switch cfg.EvictionPolicy {
+	case EvictEmit, EvictDrop:
+	default:
+		return fmt.Errorf("unknown eviction_policy %q, must be %q or %q", cfg.EvictionPolicy, EvictEmit, EvictDrop)
+	}
+	return nil
+}
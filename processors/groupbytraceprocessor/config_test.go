@@ -0,0 +1,44 @@
+package groupbytraceprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	gCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, 30*time.Second, gCfg.WaitDuration)
+	assert.False(t, gCfg.EmitOnRoot)
+	assert.Equal(t, 1000, gCfg.NumTraces)
+	assert.Equal(t, EvictDrop, gCfg.EvictionPolicy)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WaitDuration = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.NumTraces = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.EvictionPolicy = "oldest"
+	assert.Error(t, cfg.Validate())
+}
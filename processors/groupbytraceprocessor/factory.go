@@ -0,0 +1,49 @@
+package groupbytraceprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr               = "hypertrace_groupbytrace"
+	defaultWaitDuration   = 10 * time.Second
+	defaultNumTraces      = 100000
+	defaultEvictionPolicy = EvictEmit
+)
+
+// NewFactory creates a factory for the group by trace processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		WaitDuration:   defaultWaitDuration,
+		EmitOnRoot:     true,
+		NumTraces:      defaultNumTraces,
+		EvictionPolicy: defaultEvictionPolicy,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	return newProcessor(params.Logger, cfg.(*Config), nextConsumer), nil
+}
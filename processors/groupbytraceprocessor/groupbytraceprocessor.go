@@ -0,0 +1,227 @@
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/hypertrace/collector/internal/tracebatch"
+)
+
+// tickInterval is the period in which buffered traces are checked for expiry.
+const tickInterval = time.Second
+
+type traceData struct {
+	// seq tells apart buffer entries of the same trace ID, spans arriving after
+	// their trace was released start a new entry.
+	seq       uint64
+	arrival   time.Time
+	spanCount int
+	batches   pdata.ResourceSpansSlice
+}
+
+type orderEntry struct {
+	traceID [16]byte
+	seq     uint64
+}
+
+type releasedTrace struct {
+	trace  *traceData
+	reason string
+}
+
+type processor struct {
+	nextConsumer   consumer.Traces
+	logger         *zap.Logger
+	waitDuration   time.Duration
+	emitOnRoot     bool
+	numTraces      int
+	evictionPolicy EvictionPolicy
+
+	mu     sync.Mutex
+	traces map[[16]byte]*traceData
+	// order holds buffer entries in the order of their arrival. Entries of traces
+	// released on their root span stay until they reach the front.
+	order   []orderEntry
+	nextSeq uint64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) *processor {
+	return &processor{
+		nextConsumer:   nextConsumer,
+		logger:         logger,
+		waitDuration:   cfg.WaitDuration,
+		emitOnRoot:     cfg.EmitOnRoot,
+		numTraces:      cfg.NumTraces,
+		evictionPolicy: cfg.EvictionPolicy,
+		traces:         map[[16]byte]*traceData{},
+		done:           make(chan struct{}),
+	}
+}
+
+// Start implements component.Component
+func (p *processor) Start(context.Context, component.Host) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case now := <-ticker.C:
+				p.flush(now, false)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements component.Component. Buffered traces are released immediately.
+func (p *processor) Shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	p.flush(time.Now(), true)
+	return nil
+}
+
+// Capabilities implements consumer.Traces
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces implements consumer.Traces. Spans are buffered until their trace is complete.
+func (p *processor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	now := time.Now()
+	var released []releasedTrace
+
+	p.mu.Lock()
+	for traceID, batches := range tracebatch.SplitByTrace(td) {
+		trace, ok := p.traces[traceID]
+		if !ok {
+			p.nextSeq++
+			trace = &traceData{seq: p.nextSeq, arrival: now, batches: pdata.NewResourceSpansSlice()}
+			p.traces[traceID] = trace
+			p.order = append(p.order, orderEntry{traceID: traceID, seq: trace.seq})
+		}
+
+		rootReceived := p.emitOnRoot && containsRoot(batches)
+		trace.spanCount += tracebatch.SpanCount(batches)
+		batches.MoveAndAppendTo(trace.batches)
+		if rootReceived {
+			delete(p.traces, traceID)
+			released = append(released, releasedTrace{trace: trace, reason: reasonRoot})
+		}
+	}
+	for len(p.traces) > p.numTraces {
+		reason := reasonEvicted
+		if p.evictionPolicy == EvictDrop {
+			reason = reasonDropped
+		}
+		released = append(released, releasedTrace{trace: p.popOldest(), reason: reason})
+	}
+	p.mu.Unlock()
+
+	p.release(released)
+	return nil
+}
+
+// popOldest removes the oldest trace from the buffer. Must be called with mu held
+// and at least one trace buffered.
+func (p *processor) popOldest() *traceData {
+	for {
+		entry := p.order[0]
+		p.order = p.order[1:]
+		if trace, ok := p.traces[entry.traceID]; ok && trace.seq == entry.seq {
+			delete(p.traces, entry.traceID)
+			return trace
+		}
+	}
+}
+
+// flush releases traces buffered for longer than the wait duration, or all traces if all is set.
+func (p *processor) flush(now time.Time, all bool) {
+	reason := reasonWaitElapsed
+	if all {
+		reason = reasonShutdown
+	}
+
+	var released []releasedTrace
+	p.mu.Lock()
+	for len(p.order) > 0 {
+		entry := p.order[0]
+		trace, ok := p.traces[entry.traceID]
+		if !ok || trace.seq != entry.seq {
+			p.order = p.order[1:]
+			continue
+		}
+		if !all && trace.arrival.Add(p.waitDuration).After(now) {
+			break
+		}
+		released = append(released, releasedTrace{trace: p.popOldest(), reason: reason})
+	}
+	p.mu.Unlock()
+
+	p.release(released)
+}
+
+// release records the released traces and forwards all of them except the dropped ones
+// in a single batch.
+func (p *processor) release(traces []releasedTrace) {
+	if len(traces) == 0 {
+		return
+	}
+
+	traceCounts := map[string]int64{}
+	spanCounts := map[string]int64{}
+	td := pdata.NewTraces()
+	for _, t := range traces {
+		traceCounts[t.reason]++
+		spanCounts[t.reason] += int64(t.trace.spanCount)
+		if t.reason != reasonDropped {
+			t.trace.batches.MoveAndAppendTo(td.ResourceSpans())
+		}
+	}
+
+	for reason, count := range traceCounts {
+		ctx, _ := tag.New(context.Background(),
+			tag.Insert(tagReason, reason))
+		stats.Record(ctx, statTraceCount.M(count), statSpanCount.M(spanCounts[reason]))
+	}
+
+	if td.ResourceSpans().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeTraces(context.Background(), td); err != nil {
+		p.logger.Error("Failed to export grouped traces", zap.Error(err))
+	}
+}
+
+// containsRoot returns true if one of the spans has no parent.
+func containsRoot(batches pdata.ResourceSpansSlice) bool {
+	for i := 0; i < batches.Len(); i++ {
+		ilss := batches.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if spans.At(k).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type testSpan struct {
+	traceID  byte
+	spanID   byte
+	parentID byte
+}
+
+func generateTraces(testSpans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, ts := range testSpans {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{ts.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{ts.spanID}))
+		if ts.parentID != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([8]byte{ts.parentID}))
+		}
+	}
+	return td
+}
+
+func newTestProcessor(sink *consumertest.TracesSink, modify func(*Config)) *processor {
+	cfg := createDefaultConfig().(*Config)
+	if modify != nil {
+		modify(cfg)
+	}
+	return newProcessor(zap.NewNop(), cfg, sink)
+}
+
+// traceIDs returns the trace IDs of the spans of each received batch.
+func traceIDs(sink *consumertest.TracesSink) [][]byte {
+	var result [][]byte
+	for _, td := range sink.AllTraces() {
+		var ids []byte
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					traceID := spans.At(k).TraceID().Bytes()
+					ids = append(ids, traceID[0])
+				}
+			}
+		}
+		result = append(result, ids)
+	}
+	return result
+}
+
+func TestEmitOnRoot(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink, nil)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(
+		testSpan{traceID: 1, spanID: 2, parentID: 1},
+		testSpan{traceID: 2, spanID: 2, parentID: 1},
+	)))
+	assert.Equal(t, 0, sink.SpansCount())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(
+		testSpan{traceID: 1, spanID: 3, parentID: 1},
+		testSpan{traceID: 1, spanID: 1},
+	)))
+	assert.Equal(t, [][]byte{{1, 1, 1}}, traceIDs(sink))
+	assert.Equal(t, 1, len(p.traces))
+}
+
+func TestWaitDuration(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink, func(cfg *Config) {
+		cfg.EmitOnRoot = false
+	})
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(
+		testSpan{traceID: 1, spanID: 1},
+		testSpan{traceID: 2, spanID: 2, parentID: 1},
+	)))
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(
+		testSpan{traceID: 1, spanID: 2, parentID: 1},
+	)))
+
+	p.flush(time.Now(), false)
+	assert.Equal(t, 0, sink.SpansCount())
+
+	p.flush(time.Now().Add(defaultWaitDuration), false)
+	require.Equal(t, 1, len(sink.AllTraces()))
+	assert.ElementsMatch(t, []byte{1, 1, 2}, traceIDs(sink)[0])
+	assert.Empty(t, p.traces)
+	assert.Empty(t, p.order)
+}
+
+func TestLateSpansStartNewEntry(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink, nil)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(testSpan{traceID: 1, spanID: 1})))
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(testSpan{traceID: 1, spanID: 2, parentID: 1})))
+	assert.Equal(t, 1, sink.SpansCount())
+
+	// The order entry of the released trace must not release the late span.
+	p.mu.Lock()
+	p.traces[[16]byte{1}].arrival = time.Now().Add(time.Hour)
+	p.mu.Unlock()
+	p.flush(time.Now().Add(defaultWaitDuration), false)
+	assert.Equal(t, 1, sink.SpansCount())
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.SpansCount())
+}
+
+func TestEviction(t *testing.T) {
+	tests := []struct {
+		policy   EvictionPolicy
+		expected [][]byte
+	}{
+		{policy: EvictEmit, expected: [][]byte{{1}, {2}}},
+		{policy: EvictDrop, expected: [][]byte{{2}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			p := newTestProcessor(sink, func(cfg *Config) {
+				cfg.NumTraces = 1
+				cfg.EvictionPolicy = tt.policy
+			})
+
+			require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(testSpan{traceID: 1, spanID: 2, parentID: 1})))
+			require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(testSpan{traceID: 2, spanID: 2, parentID: 1})))
+			require.NoError(t, p.Shutdown(context.Background()))
+			assert.Equal(t, tt.expected, traceIDs(sink))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package groupbytraceprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagReason = tag.MustNewKey("reason")
+
+	statTraceCount = stats.Int64("group_by_trace_trace_count", "Number of traces released from the buffer", stats.UnitDimensionless)
+	statSpanCount  = stats.Int64("group_by_trace_span_count", "Number of spans released from the buffer", stats.UnitDimensionless)
+)
+
+// Reasons a trace is released from the buffer.
+const (
+	reasonRoot        = "root"
+	reasonWaitElapsed = "wait_elapsed"
+	reasonShutdown    = "shutdown"
+	reasonEvicted     = "evicted"
+	reasonDropped     = "dropped"
+)
+
+// MetricViews returns the metrics views for group by trace processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagReason}
+
+	viewTraceCount := &view.View{
+		Name:        statTraceCount.Name(),
+		Description: statTraceCount.Description(),
+		Measure:     statTraceCount,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	viewSpanCount := &view.View{
+		Name:        statSpanCount.Name(),
+		Description: statSpanCount.Description(),
+		Measure:     statSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewTraceCount,
+		viewSpanCount,
+	}
+}
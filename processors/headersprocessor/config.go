@@ -0,0 +1,34 @@
+package headersprocessor
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for headers processor.
+// The processor handles the http.request.header.* and http.response.header.*
+// span attributes captured by the agents. Header names are lower cased with
+// underscores replaced by dashes, e.g. http.request.header.Content_Type becomes
+// http.request.header.content-type. Credential headers and the tenant ID
+// header are always removed, other headers are filtered by the rules of the tenant.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// TenantIDHeaderName defines tenant HTTP header name which is removed. Default x-tenant-id.
+	TenantIDHeaderName string `mapstructure:"tenant_id_header_name"`
+	// DefaultRules apply to tenants without a dedicated entry in TenantRules.
+	DefaultRules RulesConfig `mapstructure:"default_rules"`
+	// TenantRules maps tenant ID to the rules applied to its spans.
+	TenantRules map[string]RulesConfig `mapstructure:"tenant_rules"`
+}
+
+// RulesConfig defines which headers are kept. Header names are case insensitive.
+type RulesConfig struct {
+	// Allow lists the headers which are kept, when empty all headers are kept.
+	Allow []string `mapstructure:"allow"`
+	// Deny lists the headers which are removed.
+	Deny []string `mapstructure:"deny"`
+}
+
+var _ config.Processor = (*Config)(nil)
@@ -0,0 +1,31 @@
+package headersprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	hCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, hCfg.TenantIDAttributeKey)
+	assert.Equal(t, "x-customer", hCfg.TenantIDHeaderName)
+	assert.Equal(t, RulesConfig{Deny: []string{"x-api-key"}}, hCfg.DefaultRules)
+	assert.Equal(t, map[string]RulesConfig{
+		"jdoe": {Allow: []string{"content-type", "user-agent", "x-forwarded-for"}},
+	}, hCfg.TenantRules)
+}
@@ -0,0 +1,180 @@
+package headersprocessor
+
+import (
+	"context"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// Prefixes of the captured header attributes.
+var headerPrefixes = []string{
+	"http.request.header.",
+	"http.response.header.",
+}
+
+// credentialHeaders are removed regardless of the rules.
+var credentialHeaders = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+}
+
+type rules struct {
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+func newRules(cfg RulesConfig) *rules {
+	return &rules{allow: nameSet(cfg.Allow), deny: nameSet(cfg.Deny)}
+}
+
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[canonicalName(name)] = true
+	}
+	return set
+}
+
+type processor struct {
+	tenantIDAttributeKey string
+	stripped             map[string]bool
+	defaultRules         *rules
+	tenantRules          map[string]*rules
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	tenantRules := make(map[string]*rules, len(cfg.TenantRules))
+	for tenantID, r := range cfg.TenantRules {
+		tenantRules[tenantID] = newRules(r)
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		stripped:             nameSet(append([]string{cfg.TenantIDHeaderName}, credentialHeaders...)),
+		defaultRules:         newRules(cfg.DefaultRules),
+		tenantRules:          tenantRules,
+	}
+}
+
+// removedCounts counts removed headers per tenant and reason.
+type removedCounts map[string]map[string]int64
+
+func (c removedCounts) inc(tenantID, reason string) {
+	if _, ok := c[tenantID]; !ok {
+		c[tenantID] = map[string]int64{}
+	}
+	c[tenantID][reason]++
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	removed := removedCounts{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				r, ok := p.tenantRules[tenantID]
+				if !ok {
+					r = p.defaultRules
+				}
+				for _, reason := range p.processHeaders(span.Attributes(), r) {
+					removed.inc(tenantID, reason)
+				}
+			}
+		}
+	}
+
+	for tenantID, reasons := range removed {
+		for reason, count := range reasons {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagReason, reason))
+			stats.Record(tCtx, statRemovedHeaderCount.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+type headerAttribute struct {
+	key       string
+	canonical string
+	name      string
+}
+
+// processHeaders renames header attributes to their canonical key and removes
+// the ones which are not kept. It returns the reason of every removal.
+func (p *processor) processHeaders(attrs pdata.AttributeMap, r *rules) []string {
+	var headers []headerAttribute
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		if prefix, name, ok := splitHeaderKey(k); ok {
+			name = canonicalName(name)
+			headers = append(headers, headerAttribute{key: k, canonical: prefix + name, name: name})
+		}
+		return true
+	})
+
+	var reasons []string
+	for _, h := range headers {
+		reason := ""
+		switch {
+		case p.stripped[h.name]:
+			reason = reasonCredential
+		case r.deny[h.name]:
+			reason = reasonDenied
+		case len(r.allow) > 0 && !r.allow[h.name]:
+			reason = reasonNotAllowed
+		}
+
+		if reason == "" && h.key != h.canonical {
+			value, _ := attrs.Get(h.key)
+			if _, exists := attrs.Get(h.canonical); exists {
+				// The same header was captured with a different case, keep the canonical one.
+				reason = reasonDuplicate
+			} else {
+				attrs.Insert(h.canonical, value)
+			}
+		}
+		if reason != "" || h.key != h.canonical {
+			attrs.Delete(h.key)
+		}
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// splitHeaderKey returns the canonical prefix and the header name if the key
+// is a captured header attribute. The prefix is matched case insensitively.
+func splitHeaderKey(key string) (string, string, bool) {
+	for _, prefix := range headerPrefixes {
+		if len(key) > len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+			return prefix, key[len(prefix):], true
+		}
+	}
+	return "", "", false
+}
+
+func canonicalName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
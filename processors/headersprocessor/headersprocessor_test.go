@@ -0,0 +1,86 @@
+package headersprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestProcessor() *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultRules = RulesConfig{Deny: []string{"X-Api-Key"}}
+	cfg.TenantRules = map[string]RulesConfig{
+		"strict": {Allow: []string{"content-type"}},
+	}
+	return newProcessor(cfg)
+}
+
+func process(t *testing.T, tenantID string, attrs map[string]string) map[string]string {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	for k, v := range attrs {
+		span.Attributes().InsertString(k, v)
+	}
+
+	td, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	result := map[string]string{}
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		if k != defaultTenantIDAttributeKey {
+			result[k] = v.StringVal()
+		}
+		return true
+	})
+	return result
+}
+
+func TestCanonicalizeAndStrip(t *testing.T) {
+	result := process(t, "jdoe", map[string]string{
+		"http.request.header.Content-Type":   "application/json",
+		"HTTP.Request.Header.x_request_id":   "42",
+		"http.request.header.Authorization":  "Bearer token",
+		"http.request.header.cookie":         "session=1",
+		"http.request.header.X-Tenant-ID":    "jdoe",
+		"http.request.header.x-api-key":      "secret",
+		"http.response.header.Set-Cookie":    "session=2",
+		"http.response.header.Cache-Control": "no-cache",
+		"http.method":                        "GET",
+	})
+
+	assert.Equal(t, map[string]string{
+		"http.request.header.content-type":   "application/json",
+		"http.request.header.x-request-id":   "42",
+		"http.response.header.cache-control": "no-cache",
+		"http.method":                        "GET",
+	}, result)
+}
+
+func TestAllowList(t *testing.T) {
+	result := process(t, "strict", map[string]string{
+		"http.request.header.content-type":  "application/json",
+		"http.request.header.accept":        "*/*",
+		"http.request.header.authorization": "Basic YTpi",
+		"http.response.header.Content-Type": "text/plain",
+	})
+
+	assert.Equal(t, map[string]string{
+		"http.request.header.content-type":  "application/json",
+		"http.response.header.content-type": "text/plain",
+	}, result)
+}
+
+func TestDuplicateHeaderKeepsCanonical(t *testing.T) {
+	result := process(t, "jdoe", map[string]string{
+		"http.request.header.accept": "text/html",
+		"http.request.header.Accept": "*/*",
+	})
+
+	assert.Equal(t, map[string]string{
+		"http.request.header.accept": "text/html",
+	}, result)
+}
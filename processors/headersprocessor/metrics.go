@@ -0,0 +1,37 @@
+package headersprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagReason   = tag.MustNewKey("reason")
+
+	statRemovedHeaderCount = stats.Int64("headers_removed_header_count", "Number of captured headers removed from spans", stats.UnitDimensionless)
+)
+
+// Reasons a header is removed.
+const (
+	reasonCredential = "credential"
+	reasonDenied     = "denied"
+	reasonNotAllowed = "not_allowed"
+	reasonDuplicate  = "duplicate"
+)
+
+// MetricViews returns the metrics views for headers processor.
+func MetricViews() []*view.View {
+	viewRemovedHeaderCount := &view.View{
+		Name:        statRemovedHeaderCount.Name(),
+		Description: statRemovedHeaderCount.Description(),
+		Measure:     statRemovedHeaderCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagReason},
+	}
+
+	return []*view.View{
+		viewRemovedHeaderCount,
+	}
+}
@@ -0,0 +1,48 @@
+package headsamplingprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for head sampling processor.
+// The processor keeps a percentage of traces configured per tenant. The decision
+// is based on a hash of the trace ID, therefore all collectors sharing the same
+// configuration keep the same traces. The tenant is read from the tenant ID
+// attribute, therefore this processor has to run after the tenant ID processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// DefaultSamplingPercentage is used for tenants not listed in TenantSamplingPercentages. Default 100.
+	DefaultSamplingPercentage float64 `mapstructure:"default_sampling_percentage"`
+	// TenantSamplingPercentages maps tenant ID to the percentage of its traces to keep.
+	TenantSamplingPercentages map[string]float64 `mapstructure:"tenant_sampling_percentages"`
+	// HashSeed is mixed into the trace ID hash. Collectors have to use the same
+	// seed to make the same decisions.
+	HashSeed uint32 `mapstructure:"hash_seed"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := validatePercentage(cfg.DefaultSamplingPercentage); err != nil {
+		return fmt.Errorf("invalid default_sampling_percentage: %w", err)
+	}
+	for tenantID, percentage := range cfg.TenantSamplingPercentages {
+		if err := validatePercentage(percentage); err != nil {
+			return fmt.Errorf("invalid sampling percentage for tenant %q: %w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+func validatePercentage(percentage float64) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("%v is not between 0 and 100", percentage)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package headsamplingprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	hsCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", hsCfg.TenantIDAttributeKey)
+	assert.Equal(t, float64(50), hsCfg.DefaultSamplingPercentage)
+	assert.Equal(t, uint32(22), hsCfg.HashSeed)
+	assert.Equal(t, map[string]float64{"big-tenant": 5}, hsCfg.TenantSamplingPercentages)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultSamplingPercentage = 101
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.TenantSamplingPercentages = map[string]float64{"jdoe": -1}
+	assert.Error(t, cfg.Validate())
+}
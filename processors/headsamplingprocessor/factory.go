@@ -0,0 +1,48 @@
+package headsamplingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                          = "hypertrace_headsampling"
+	defaultTenantIDAttributeKey      = "tenant-id"
+	defaultDefaultSamplingPercentage = 100
+)
+
+// NewFactory creates a factory for the head sampling processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey:      defaultTenantIDAttributeKey,
+		DefaultSamplingPercentage: defaultDefaultSamplingPercentage,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		newProcessor(cfg.(*Config)))
+}
@@ -0,0 +1,14 @@
+package headsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, float64(defaultDefaultSamplingPercentage), cfg.DefaultSamplingPercentage)
+	assert.NoError(t, cfg.Validate())
+}
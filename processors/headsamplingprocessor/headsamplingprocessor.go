@@ -0,0 +1,94 @@
+package headsamplingprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+	// thresholds are compared against the trace ID hash, a trace is kept when its hash is lower.
+	defaultThreshold uint64
+	tenantThresholds map[string]uint64
+	hashSeed         uint32
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	tenantThresholds := make(map[string]uint64, len(cfg.TenantSamplingPercentages))
+	for tenantID, percentage := range cfg.TenantSamplingPercentages {
+		tenantThresholds[tenantID] = percentageToThreshold(percentage)
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		defaultThreshold:     percentageToThreshold(cfg.DefaultSamplingPercentage),
+		tenantThresholds:     tenantThresholds,
+		hashSeed:             cfg.HashSeed,
+	}
+}
+
+func percentageToThreshold(percentage float64) uint64 {
+	return uint64(percentage / 100 * (math.MaxUint32 + 1))
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	sampledOut := map[string]int64{}
+
+	traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				if p.keep(tenantID, span.TraceID()) {
+					return false
+				}
+				sampledOut[tenantID]++
+				return true
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+
+	for tenantID, count := range sampledOut {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statSampledOutSpanPerTenant.M(count))
+	}
+
+	if traces.ResourceSpans().Len() == 0 {
+		return traces, processorhelper.ErrSkipProcessingData
+	}
+	return traces, nil
+}
+
+func (p *processor) keep(tenantID string, traceID pdata.TraceID) bool {
+	threshold, ok := p.tenantThresholds[tenantID]
+	if !ok {
+		threshold = p.defaultThreshold
+	}
+	return uint64(p.hash(traceID)) < threshold
+}
+
+// hash returns a hash of the seeded trace ID which is stable across collector instances.
+func (p *processor) hash(traceID pdata.TraceID) uint32 {
+	var seed [4]byte
+	binary.BigEndian.PutUint32(seed[:], p.hashSeed)
+	b := traceID.Bytes()
+	h := fnv.New32a()
+	h.Write(seed[:])
+	h.Write(b[:])
+	return h.Sum32()
+}
@@ -0,0 +1,62 @@
+package headsamplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func generateTraces(tenantID string, numTraces int) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i := 0; i < numTraces; i++ {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{byte(i), byte(i >> 8), 1}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1}))
+		span.Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	}
+	return td
+}
+
+func newTestProcessor() *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantSamplingPercentages = map[string]float64{
+		"big":   5,
+		"muted": 0,
+	}
+	return newProcessor(cfg)
+}
+
+func TestDefaultPercentageKeepsEverything(t *testing.T) {
+	p := newTestProcessor()
+	td, err := p.ProcessTraces(context.Background(), generateTraces("small", 1000))
+	require.NoError(t, err)
+	assert.Equal(t, 1000, td.SpanCount())
+}
+
+func TestTenantPercentage(t *testing.T) {
+	p := newTestProcessor()
+	td, err := p.ProcessTraces(context.Background(), generateTraces("big", 1000))
+	require.NoError(t, err)
+	assert.InDelta(t, 50, td.SpanCount(), 25)
+}
+
+func TestAllSpansSampledOut(t *testing.T) {
+	p := newTestProcessor()
+	_, err := p.ProcessTraces(context.Background(), generateTraces("muted", 10))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestDecisionIsDeterministic(t *testing.T) {
+	first, err := newTestProcessor().ProcessTraces(context.Background(), generateTraces("big", 1000))
+	require.NoError(t, err)
+	second, err := newTestProcessor().ProcessTraces(context.Background(), generateTraces("big", 1000))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
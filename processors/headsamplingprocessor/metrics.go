@@ -0,0 +1,32 @@
+package headsamplingprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+
+	// statSampledOutSpanPerTenant complements tenant_id_span_count of the tenant ID
+	// processor: kept spans are the received ones minus the sampled out ones.
+	statSampledOutSpanPerTenant = stats.Int64("tenant_id_sampled_out_span_count", "Number of spans from a tenant dropped by head sampling", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for head sampling processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID}
+
+	viewSampledOutSpanCount := &view.View{
+		Name:        statSampledOutSpanPerTenant.Name(),
+		Description: statSampledOutSpanPerTenant.Description(),
+		Measure:     statSampledOutSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewSampledOutSpanCount,
+	}
+}
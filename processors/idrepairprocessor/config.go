@@ -0,0 +1,21 @@
+package idrepairprocessor
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for ID repair processor.
+// The processor stores 64-bit trace IDs left-padded with zeros and extends them
+// to the 128-bit trace ID of the batch sharing the same lower 64 bits.
+// Zero span IDs and span IDs colliding within a trace are regenerated and
+// the parent span IDs of their children are updated.
+// Spans with a zero trace ID or being their own parent are dropped.
+// Collisions are only detected between spans of the same batch.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+}
+
+var _ config.Processor = (*Config)(nil)
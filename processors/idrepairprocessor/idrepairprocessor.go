@@ -0,0 +1,234 @@
+package idrepairprocessor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+type spanRef struct {
+	span     pdata.Span
+	tenantID string
+	// resource is the index of the resource spans, spans of different resources come from different services.
+	resource int
+}
+
+// reasonCounts counts spans per tenant and reason.
+type reasonCounts map[string]map[string]int64
+
+func (c reasonCounts) inc(tenantID, reason string) {
+	if _, ok := c[tenantID]; !ok {
+		c[tenantID] = map[string]int64{}
+	}
+	c[tenantID][reason]++
+}
+
+func (c reasonCounts) record(ctx context.Context, measure *stats.Int64Measure) {
+	for tenantID, reasons := range c {
+		for reason, count := range reasons {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagReason, reason))
+			stats.Record(tCtx, measure.M(count))
+		}
+	}
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	repaired := reasonCounts{}
+	dropped := reasonCounts{}
+
+	refs := p.collectSpans(traces)
+
+	// 64-bit trace IDs are stored in the lower half. Normalize right-padded IDs
+	// and remember 128-bit IDs to extend 64-bit IDs of the same trace.
+	fullTraceIDs := map[uint64][16]byte{}
+	for _, ref := range refs {
+		traceID := ref.span.TraceID().Bytes()
+		if high, low := splitTraceID(traceID); high != 0 && low == 0 {
+			traceID = joinTraceID(0, high)
+			ref.span.SetTraceID(pdata.NewTraceID(traceID))
+			repaired.inc(ref.tenantID, reasonPaddedTraceID)
+		}
+		if high, low := splitTraceID(traceID); high != 0 {
+			fullTraceIDs[low] = traceID
+		}
+	}
+
+	spansByTrace := map[[16]byte][]*spanRef{}
+	drop := map[pdata.Span]bool{}
+	for _, ref := range refs {
+		traceID := ref.span.TraceID().Bytes()
+		if high, low := splitTraceID(traceID); high == 0 && low != 0 {
+			if fullTraceID, ok := fullTraceIDs[low]; ok {
+				traceID = fullTraceID
+				ref.span.SetTraceID(pdata.NewTraceID(traceID))
+				repaired.inc(ref.tenantID, reasonShortTraceID)
+			}
+		}
+
+		switch {
+		case ref.span.TraceID().IsEmpty():
+			drop[ref.span] = true
+			dropped.inc(ref.tenantID, reasonZeroTraceID)
+		case !ref.span.SpanID().IsEmpty() && ref.span.SpanID() == ref.span.ParentSpanID():
+			drop[ref.span] = true
+			dropped.inc(ref.tenantID, reasonSelfParent)
+		default:
+			spansByTrace[traceID] = append(spansByTrace[traceID], ref)
+		}
+	}
+
+	for _, traceSpans := range spansByTrace {
+		repairSpanIDs(traceSpans, repaired)
+	}
+
+	if len(drop) > 0 {
+		traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+			rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+				ils.Spans().RemoveIf(func(span pdata.Span) bool {
+					return drop[span]
+				})
+				return ils.Spans().Len() == 0
+			})
+			return rs.InstrumentationLibrarySpans().Len() == 0
+		})
+	}
+
+	repaired.record(ctx, statRepairedSpanPerTenant)
+	dropped.record(ctx, statDroppedSpanPerTenant)
+
+	if traces.ResourceSpans().Len() == 0 {
+		return traces, processorhelper.ErrSkipProcessingData
+	}
+	return traces, nil
+}
+
+func (p *processor) collectSpans(traces pdata.Traces) []*spanRef {
+	var refs []*spanRef
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				refs = append(refs, &spanRef{span: span, tenantID: tenantID, resource: i})
+			}
+		}
+	}
+	return refs
+}
+
+// repairSpanIDs regenerates zero and colliding span IDs of a single trace.
+func repairSpanIDs(traceSpans []*spanRef, repaired reasonCounts) {
+	used := map[[8]byte]bool{}
+	byID := map[[8]byte][]*spanRef{}
+	var ids [][8]byte
+	for _, ref := range traceSpans {
+		id := ref.span.SpanID().Bytes()
+		used[id] = true
+		if _, ok := byID[id]; !ok {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], ref)
+	}
+
+	for _, id := range ids {
+		refs := byID[id]
+		if pdata.NewSpanID(id).IsEmpty() {
+			// Nothing can reference a zero span ID, a new ID does not need any parent update.
+			for _, ref := range refs {
+				ref.span.SetSpanID(pdata.NewSpanID(newSpanID(used)))
+				repaired.inc(ref.tenantID, reasonZeroSpanID)
+			}
+			continue
+		}
+		if len(refs) < 2 {
+			continue
+		}
+
+		kept := refs[0]
+		for _, ref := range refs {
+			if ref.span.Kind() == pdata.SpanKindClient {
+				kept = ref
+				break
+			}
+		}
+		for _, dup := range refs {
+			if dup == kept || isRetry(kept.span, dup.span) {
+				continue
+			}
+
+			newID := newSpanID(used)
+			if dup.resource != kept.resource {
+				// Children reported by the same service as the duplicate belong to it.
+				for _, child := range traceSpans {
+					if child != dup && child.resource == dup.resource && child.span.ParentSpanID().Bytes() == id {
+						child.span.SetParentSpanID(pdata.NewSpanID(newID))
+					}
+				}
+			}
+			if dup.span.Kind() == pdata.SpanKindServer && kept.span.Kind() == pdata.SpanKindClient &&
+				dup.span.ParentSpanID() == kept.span.ParentSpanID() {
+				// Zipkin shares the span ID between client and server, the server is a child of the client.
+				dup.span.SetParentSpanID(pdata.NewSpanID(id))
+			}
+			dup.span.SetSpanID(pdata.NewSpanID(newID))
+			repaired.inc(dup.tenantID, reasonDuplicateSpanID)
+		}
+	}
+}
+
+// isRetry returns true when both spans describe the same operation, e.g. because
+// the span was sent twice. Such duplicates are not collisions.
+func isRetry(a, b pdata.Span) bool {
+	return a.Name() == b.Name() &&
+		a.Kind() == b.Kind() &&
+		a.StartTimestamp() == b.StartTimestamp() &&
+		a.EndTimestamp() == b.EndTimestamp()
+}
+
+// newSpanID returns a random non-zero span ID not present in used and adds it to used.
+func newSpanID(used map[[8]byte]bool) [8]byte {
+	for {
+		var id [8]byte
+		if _, err := rand.Read(id[:]); err != nil {
+			continue
+		}
+		if id != [8]byte{} && !used[id] {
+			used[id] = true
+			return id
+		}
+	}
+}
+
+func splitTraceID(traceID [16]byte) (uint64, uint64) {
+	return binary.BigEndian.Uint64(traceID[:8]), binary.BigEndian.Uint64(traceID[8:])
+}
+
+func joinTraceID(high, low uint64) [16]byte {
+	var traceID [16]byte
+	binary.BigEndian.PutUint64(traceID[:8], high)
+	binary.BigEndian.PutUint64(traceID[8:], low)
+	return traceID
+}
@@ -0,0 +1,120 @@
+package idrepairprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var (
+	testTraceID      = pdata.NewTraceID([16]byte{0xa, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8})
+	testShortTraceID = pdata.NewTraceID([16]byte{8: 1, 2, 3, 4, 5, 6, 7, 8})
+)
+
+func newTestProcessor() *processor {
+	return &processor{tenantIDAttributeKey: defaultTenantIDAttributeKey}
+}
+
+func addSpans(td pdata.Traces) pdata.SpanSlice {
+	return td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+}
+
+func addSpan(spans pdata.SpanSlice, traceID pdata.TraceID, id, parentID byte) pdata.Span {
+	span := spans.AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(pdata.NewSpanID([8]byte{7: id}))
+	if parentID != 0 {
+		span.SetParentSpanID(pdata.NewSpanID([8]byte{7: parentID}))
+	}
+	return span
+}
+
+func TestRightPaddedTraceIDIsLeftPadded(t *testing.T) {
+	td := pdata.NewTraces()
+	span := addSpan(addSpans(td), pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8}), 1, 0)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, testShortTraceID, span.TraceID())
+}
+
+func TestShortTraceIDIsExtended(t *testing.T) {
+	td := pdata.NewTraces()
+	addSpan(addSpans(td), testTraceID, 1, 0)
+	child := addSpan(addSpans(td), testShortTraceID, 2, 1)
+	other := addSpan(addSpans(td), pdata.NewTraceID([16]byte{15: 9}), 3, 0)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, testTraceID, child.TraceID())
+	assert.Equal(t, pdata.NewTraceID([16]byte{15: 9}), other.TraceID())
+}
+
+func TestInvalidSpansAreDropped(t *testing.T) {
+	td := pdata.NewTraces()
+	spans := addSpans(td)
+	addSpan(spans, pdata.InvalidTraceID(), 1, 0)
+	addSpan(spans, testTraceID, 2, 2)
+	addSpan(spans, testTraceID, 3, 0)
+
+	td, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+	assert.Equal(t, pdata.NewSpanID([8]byte{7: 3}), spans.At(0).SpanID())
+}
+
+func TestAllSpansDropped(t *testing.T) {
+	td := pdata.NewTraces()
+	addSpan(addSpans(td), pdata.InvalidTraceID(), 1, 0)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestZeroSpanIDIsRegenerated(t *testing.T) {
+	td := pdata.NewTraces()
+	span := addSpan(addSpans(td), testTraceID, 0, 0)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.False(t, span.SpanID().IsEmpty())
+}
+
+func TestSharedZipkinSpanIDIsRepaired(t *testing.T) {
+	td := pdata.NewTraces()
+	clientSpans := addSpans(td)
+	root := addSpan(clientSpans, testTraceID, 1, 0)
+	client := addSpan(clientSpans, testTraceID, 2, 1)
+	client.SetKind(pdata.SpanKindClient)
+	client.SetName("client")
+
+	serverSpans := addSpans(td)
+	server := addSpan(serverSpans, testTraceID, 2, 1)
+	server.SetKind(pdata.SpanKindServer)
+	server.SetName("server")
+	serverChild := addSpan(serverSpans, testTraceID, 3, 2)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, pdata.NewSpanID([8]byte{7: 2}), client.SpanID())
+	assert.Equal(t, root.SpanID(), client.ParentSpanID())
+	assert.NotEqual(t, client.SpanID(), server.SpanID())
+	assert.Equal(t, client.SpanID(), server.ParentSpanID())
+	assert.Equal(t, server.SpanID(), serverChild.ParentSpanID())
+}
+
+func TestRetriedSpanIsNotRepaired(t *testing.T) {
+	td := pdata.NewTraces()
+	addSpan(addSpans(td), testTraceID, 1, 0)
+	addSpan(addSpans(td), testTraceID, 1, 0)
+	expected := td.Clone()
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
@@ -0,0 +1,50 @@
+package idrepairprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagReason   = tag.MustNewKey("reason")
+
+	statRepairedSpanPerTenant = stats.Int64("id_repair_repaired_span_count", "Number of spans from a tenant with repaired IDs", stats.UnitDimensionless)
+	statDroppedSpanPerTenant  = stats.Int64("id_repair_dropped_span_count", "Number of spans from a tenant dropped because of invalid IDs", stats.UnitDimensionless)
+)
+
+const (
+	reasonPaddedTraceID   = "padded_trace_id"
+	reasonShortTraceID    = "short_trace_id"
+	reasonZeroSpanID      = "zero_span_id"
+	reasonDuplicateSpanID = "duplicate_span_id"
+	reasonZeroTraceID     = "zero_trace_id"
+	reasonSelfParent      = "self_parent"
+)
+
+// MetricViews returns the metrics views for ID repair processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID, tagReason}
+
+	viewRepairedSpanCount := &view.View{
+		Name:        statRepairedSpanPerTenant.Name(),
+		Description: statRepairedSpanPerTenant.Description(),
+		Measure:     statRepairedSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	viewDroppedSpanCount := &view.View{
+		Name:        statDroppedSpanPerTenant.Name(),
+		Description: statDroppedSpanPerTenant.Description(),
+		Measure:     statDroppedSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewRepairedSpanCount,
+		viewDroppedSpanCount,
+	}
+}
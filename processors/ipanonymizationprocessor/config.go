@@ -0,0 +1,110 @@
+package ipanonymizationprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configparser"
+)
+
+// Method is the way IP addresses are anonymized.
+type Method string
+
+const (
+	// Truncate zeroes the host bits of the address.
+	Truncate Method = "truncate"
+	// HMAC replaces the address with its keyed hash, addresses stay comparable
+	// but can not be recovered without the key.
+	HMAC Method = "hmac"
+)
+
+// Config defines config for IP anonymization processor.
+// The processor anonymizes the IP addresses found in the configured span and
+// resource attributes of the selected tenants. Attributes holding lists of
+// addresses, like the X-Forwarded-For header, have every address replaced.
+// The resource attributes are anonymized when the resource or one of its
+// spans belongs to a selected tenant.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Tenants maps the IDs of the tenants whose addresses are anonymized to the anonymization settings.
+	Tenants map[string]AnonymizationConfig `mapstructure:"tenants"`
+	// Attributes are the span and resource attributes which may contain IP addresses. Default
+	// net.peer.ip, http.client_ip, the legacy peer.ipv4 and peer.ipv6 and the captured
+	// X-Forwarded-For, X-Real-IP, True-Client-IP and Forwarded headers.
+	Attributes []string `mapstructure:"attributes"`
+}
+
+// AnonymizationConfig defines how the addresses of a tenant are anonymized.
+type AnonymizationConfig struct {
+	// Method is either truncate or hmac. Default truncate.
+	Method Method `mapstructure:"method"`
+	// IPv4PrefixLength is the number of leading bits kept of IPv4 addresses
+	// by the truncate method. Default 24, which zeroes the last octet.
+	IPv4PrefixLength int `mapstructure:"ipv4_prefix_length"`
+	// IPv6PrefixLength is the number of leading bits kept of IPv6 addresses
+	// by the truncate method. Default 48.
+	IPv6PrefixLength int `mapstructure:"ipv6_prefix_length"`
+	// HMACKey is the secret key of the hmac method.
+	HMACKey string `mapstructure:"hmac_key"`
+}
+
+var _ config.Processor = (*Config)(nil)
+var _ config.CustomUnmarshable = (*Config)(nil)
+
+// Unmarshal unmarshals the configuration, the anonymization settings of every
+// tenant start from the defaults.
+func (cfg *Config) Unmarshal(componentParser *configparser.Parser) error {
+	if componentParser == nil {
+		return nil
+	}
+	if componentParser.IsSet("attributes") {
+		// The configured attributes replace the default ones instead of overwriting them by index.
+		cfg.Attributes = nil
+	}
+	if err := componentParser.UnmarshalExact(cfg); err != nil {
+		return err
+	}
+
+	tenants, _ := componentParser.Get("tenants").(map[string]interface{})
+	for tenantID, settings := range tenants {
+		a := defaultAnonymizationConfig()
+		m, _ := settings.(map[string]interface{})
+		if err := configparser.NewParserFromStringMap(m).UnmarshalExact(&a); err != nil {
+			return fmt.Errorf("invalid anonymization of tenant %q: %w", tenantID, err)
+		}
+		cfg.Tenants[tenantID] = a
+	}
+	return nil
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for tenantID, a := range cfg.Tenants {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("invalid anonymization of tenant %q: %w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+func (a AnonymizationConfig) validate() error {
+	switch a.Method {
+	case "", Truncate:
+		if a.IPv4PrefixLength < 0 || a.IPv4PrefixLength > 32 {
+			return fmt.Errorf("ipv4_prefix_length must be between 0 and 32, got %d", a.IPv4PrefixLength)
+		}
+		if a.IPv6PrefixLength < 0 || a.IPv6PrefixLength > 128 {
+			return fmt.Errorf("ipv6_prefix_length must be between 0 and 128, got %d", a.IPv6PrefixLength)
+		}
+	case HMAC:
+		if a.HMACKey == "" {
+			return fmt.Errorf("hmac_key is required by the %q method", HMAC)
+		}
+	default:
+		return fmt.Errorf("unknown method %q", a.Method)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package ipanonymizationprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	iaCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, map[string]AnonymizationConfig{
+		"eu-tenant":  {Method: Truncate, IPv4PrefixLength: 16, IPv6PrefixLength: defaultIPv6PrefixLength},
+		"eu-hashed":  {Method: HMAC, HMACKey: "secret", IPv4PrefixLength: defaultIPv4PrefixLength, IPv6PrefixLength: defaultIPv6PrefixLength},
+		"eu-removed": {Method: Truncate},
+		"eu-default": defaultAnonymizationConfig(),
+	}, iaCfg.Tenants)
+	assert.Equal(t, []string{"net.peer.ip", "http.request.header.x-forwarded-for"}, iaCfg.Attributes)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tenants = map[string]AnonymizationConfig{"jdoe": {Method: HMAC}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Tenants = map[string]AnonymizationConfig{"jdoe": {IPv6PrefixLength: 129}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Tenants = map[string]AnonymizationConfig{"jdoe": {Method: "encrypt"}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestLoadConfigDefaultAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(configparser.NewParserFromStringMap(map[string]interface{}{
+		"tenants": map[string]interface{}{"jdoe": nil},
+	})))
+	assert.Equal(t, defaultAttributes, cfg.Attributes)
+	assert.Equal(t, defaultAnonymizationConfig(), cfg.Tenants["jdoe"])
+
+	cfg = createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(configparser.NewParserFromStringMap(map[string]interface{}{
+		"attributes": []interface{}{"client.ip"},
+	})))
+	assert.Equal(t, []string{"client.ip"}, cfg.Attributes)
+
+	cfg = createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Unmarshal(configparser.NewParserFromStringMap(map[string]interface{}{
+		"tenants": map[string]interface{}{"jdoe": map[string]interface{}{"prefix_length": 8}},
+	})))
+}
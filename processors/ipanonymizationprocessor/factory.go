@@ -0,0 +1,73 @@
+package ipanonymizationprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	typeStr                     = "hypertrace_ipanonymization"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultIPv4PrefixLength     = 24
+	defaultIPv6PrefixLength     = 48
+)
+
+// defaultAttributes are the attributes anonymized when none are configured.
+var defaultAttributes = []string{
+	conventions.AttributeNetPeerIP,
+	conventions.AttributeHTTPClientIP,
+	"http.request.header.x-forwarded-for",
+	"http.request.header.x-real-ip",
+	"http.request.header.true-client-ip",
+	"http.request.header.forwarded",
+	// Legacy OpenTracing attributes, peer.ipv4 holds the address as an integer.
+	"peer.ipv4",
+	"peer.ipv6",
+}
+
+// NewFactory creates a factory for the IP anonymization processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		Attributes:           defaultAttributes,
+	}
+}
+
+// defaultAnonymizationConfig returns the anonymization settings of a tenant
+// before its configuration is unmarshaled.
+func defaultAnonymizationConfig() AnonymizationConfig {
+	return AnonymizationConfig{
+		Method:           Truncate,
+		IPv4PrefixLength: defaultIPv4PrefixLength,
+		IPv6PrefixLength: defaultIPv6PrefixLength,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		newProcessor(cfg.(*Config)))
+}
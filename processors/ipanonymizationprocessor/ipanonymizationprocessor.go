@@ -0,0 +1,185 @@
+package ipanonymizationprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"net"
+	"regexp"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// hashLength is the number of bytes of the HMAC kept in the attribute value.
+const hashLength = 16
+
+// addressCandidate matches strings which may be an IP address, optionally
+// followed by a port. The candidates are validated by net.ParseIP.
+var addressCandidate = regexp.MustCompile(`[0-9A-Fa-f:.]*[.:][0-9A-Fa-f:.]*`)
+
+type anonymizer struct {
+	method   Method
+	ipv4Mask net.IPMask
+	ipv6Mask net.IPMask
+	hmacKey  []byte
+}
+
+func newAnonymizer(cfg AnonymizationConfig) *anonymizer {
+	return &anonymizer{
+		method:   cfg.Method,
+		ipv4Mask: net.CIDRMask(cfg.IPv4PrefixLength, 8*net.IPv4len),
+		ipv6Mask: net.CIDRMask(cfg.IPv6PrefixLength, 8*net.IPv6len),
+		hmacKey:  []byte(cfg.HMACKey),
+	}
+}
+
+type processor struct {
+	tenantIDAttributeKey string
+	attributes           []string
+	anonymizers          map[string]*anonymizer
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) *processor {
+	anonymizers := make(map[string]*anonymizer, len(cfg.Tenants))
+	for tenantID, a := range cfg.Tenants {
+		anonymizers[tenantID] = newAnonymizer(a)
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		attributes:           cfg.Attributes,
+		anonymizers:          anonymizers,
+	}
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	if len(p.anonymizers) == 0 {
+		return traces, nil
+	}
+	anonymized := map[string]int64{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		// The resource is anonymized as its tenant or, without a selected tenant,
+		// as the first span of a selected tenant.
+		resourceAnonymizer := p.anonymizers[resourceTenantID.StringVal()]
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				a, ok := p.anonymizers[tenantID]
+				if !ok {
+					continue
+				}
+				if resourceAnonymizer == nil {
+					resourceAnonymizer = a
+				}
+				if p.anonymizeAttributes(span.Attributes(), a) {
+					anonymized[tenantID]++
+				}
+			}
+		}
+		if resourceAnonymizer != nil {
+			p.anonymizeAttributes(rs.Resource().Attributes(), resourceAnonymizer)
+		}
+	}
+
+	for tenantID, count := range anonymized {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statAnonymizedSpanCount.M(count))
+	}
+
+	return traces, nil
+}
+
+// anonymizeAttributes replaces the addresses in the configured attributes and
+// returns true if any was replaced.
+func (p *processor) anonymizeAttributes(attrs pdata.AttributeMap, a *anonymizer) bool {
+	changed := false
+	for _, key := range p.attributes {
+		attr, ok := attrs.Get(key)
+		if !ok {
+			continue
+		}
+		switch attr.Type() {
+		case pdata.AttributeValueTypeString:
+			if value := a.anonymizeString(attr.StringVal()); value != attr.StringVal() {
+				attr.SetStringVal(value)
+				changed = true
+			}
+		case pdata.AttributeValueTypeInt:
+			if a.anonymizeInt(attr) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// anonymizeInt replaces an IPv4 address held as an integer, like the legacy
+// peer.ipv4 attribute. The hmac method replaces it with a string.
+func (a *anonymizer) anonymizeInt(attr pdata.AttributeValue) bool {
+	v := attr.IntVal()
+	if v < 0 || v > math.MaxUint32 {
+		return false
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(v))
+	if a.method == HMAC {
+		attr.SetStringVal(a.anonymize(ip))
+		return true
+	}
+	masked := int64(binary.BigEndian.Uint32(ip.Mask(a.ipv4Mask)))
+	if masked == v {
+		return false
+	}
+	attr.SetIntVal(masked)
+	return true
+}
+
+// anonymizeString replaces every IP address in s, e.g. in "203.0.113.7, 10.0.0.1:8080"
+// or "for=\"[2001:db8::1]:443\"".
+func (a *anonymizer) anonymizeString(s string) string {
+	return addressCandidate.ReplaceAllStringFunc(s, func(candidate string) string {
+		if ip := net.ParseIP(candidate); ip != nil {
+			return a.anonymize(ip)
+		}
+		// An IPv4 address with a port, IPv6 addresses with a port are bracketed.
+		if host, port, err := net.SplitHostPort(candidate); err == nil {
+			if ip := net.ParseIP(host); ip != nil && ip.To4() != nil {
+				return net.JoinHostPort(a.anonymize(ip), port)
+			}
+		}
+		return candidate
+	})
+}
+
+func (a *anonymizer) anonymize(ip net.IP) string {
+	if a.method == HMAC {
+		mac := hmac.New(sha256.New, a.hmacKey)
+		mac.Write([]byte(ip.String()))
+		return hex.EncodeToString(mac.Sum(nil)[:hashLength])
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(a.ipv4Mask).String()
+	}
+	return ip.Mask(a.ipv6Mask).String()
+}
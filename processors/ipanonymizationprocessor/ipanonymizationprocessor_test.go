@@ -0,0 +1,134 @@
+package ipanonymizationprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestAnonymizeString(t *testing.T) {
+	a := newAnonymizer(defaultAnonymizationConfig())
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "203.0.113.195", expected: "203.0.113.0"},
+		{value: "203.0.113.195, 70.41.3.18, 150.172.238.178", expected: "203.0.113.0, 70.41.3.0, 150.172.238.0"},
+		{value: "203.0.113.195:41237", expected: "203.0.113.0:41237"},
+		{value: "2001:db8:85a3:8d3:1319:8a2e:370:7348", expected: "2001:db8:85a3::"},
+		{value: `for="[2001:db8:cafe::17]:4711";proto=http, for=192.0.2.60`, expected: `for="[2001:db8:cafe::]:4711";proto=http, for=192.0.2.0`},
+		{value: "unknown", expected: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, a.anonymizeString(tt.value))
+		})
+	}
+}
+
+func TestAnonymizeZeroPrefixLength(t *testing.T) {
+	a := newAnonymizer(AnonymizationConfig{Method: Truncate})
+	assert.Equal(t, "0.0.0.0, ::", a.anonymizeString("203.0.113.195, 2001:db8::1"))
+}
+
+func TestAnonymizeHMAC(t *testing.T) {
+	a := newAnonymizer(AnonymizationConfig{Method: HMAC, HMACKey: "secret"})
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("203.0.113.195"))
+	expected := hex.EncodeToString(mac.Sum(nil)[:hashLength])
+
+	assert.Equal(t, expected+", "+expected, a.anonymizeString("203.0.113.195, 203.0.113.195"))
+	assert.NotEqual(t, expected, a.anonymizeString("203.0.113.196"))
+}
+
+func TestProcessTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tenants = map[string]AnonymizationConfig{"eu": {IPv4PrefixLength: 16}}
+	p := newProcessor(cfg)
+
+	td := pdata.NewTraces()
+	for _, tenantID := range []string{"eu", "us"} {
+		span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+		span.Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+		span.Attributes().InsertString("net.peer.ip", "198.51.100.23")
+		span.Attributes().InsertString("http.request.header.x-forwarded-for", "203.0.113.195, 198.51.100.23")
+		span.Attributes().InsertString("net.host.ip", "192.0.2.1")
+	}
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	expected := map[string]map[string]string{
+		"eu": {
+			"net.peer.ip":                         "198.51.0.0",
+			"http.request.header.x-forwarded-for": "203.0.0.0, 198.51.0.0",
+			"net.host.ip":                         "192.0.2.1",
+		},
+		"us": {
+			"net.peer.ip":                         "198.51.100.23",
+			"http.request.header.x-forwarded-for": "203.0.113.195, 198.51.100.23",
+			"net.host.ip":                         "192.0.2.1",
+		},
+	}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		attrs := td.ResourceSpans().At(i).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+		tenantID, _ := attrs.Get(defaultTenantIDAttributeKey)
+		for key, value := range expected[tenantID.StringVal()] {
+			attr, ok := attrs.Get(key)
+			require.True(t, ok)
+			assert.Equal(t, value, attr.StringVal(), key)
+		}
+	}
+}
+
+func TestProcessTracesLegacyAndResourceAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tenants = map[string]AnonymizationConfig{
+		"eu":     defaultAnonymizationConfig(),
+		"hashed": {Method: HMAC, HMACKey: "secret"},
+	}
+	p := newProcessor(cfg)
+
+	td := pdata.NewTraces()
+	for _, tenantID := range []string{"eu", "hashed", "us"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("net.peer.ip", "198.51.100.23")
+		span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+		span.Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+		// 198.51.100.23
+		span.Attributes().InsertInt("peer.ipv4", 3325256727)
+		span.Attributes().InsertString("peer.ipv6", "2001:db8:85a3:8d3:1319:8a2e:370:7348")
+	}
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	rss := td.ResourceSpans()
+	eu := rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	ipv4, _ := eu.Get("peer.ipv4")
+	// 198.51.100.0
+	assert.Equal(t, int64(3325256704), ipv4.IntVal())
+	ipv6, _ := eu.Get("peer.ipv6")
+	assert.Equal(t, "2001:db8:85a3::", ipv6.StringVal())
+	resourceIP, _ := rss.At(0).Resource().Attributes().Get("net.peer.ip")
+	assert.Equal(t, "198.51.100.0", resourceIP.StringVal())
+
+	hashed := rss.At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	ipv4, _ = hashed.Get("peer.ipv4")
+	resourceIP, _ = rss.At(1).Resource().Attributes().Get("net.peer.ip")
+	assert.Equal(t, pdata.AttributeValueTypeString, ipv4.Type())
+	assert.Equal(t, resourceIP.StringVal(), ipv4.StringVal())
+
+	us := rss.At(2).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	ipv4, _ = us.Get("peer.ipv4")
+	assert.Equal(t, int64(3325256727), ipv4.IntVal())
+	resourceIP, _ = rss.At(2).Resource().Attributes().Get("net.peer.ip")
+	assert.Equal(t, "198.51.100.23", resourceIP.StringVal())
+}
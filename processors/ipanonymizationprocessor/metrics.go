@@ -0,0 +1,28 @@
+package ipanonymizationprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+
+	statAnonymizedSpanCount = stats.Int64("ip_anonymization_anonymized_span_count", "Number of spans with anonymized IP addresses", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for IP anonymization processor.
+func MetricViews() []*view.View {
+	viewAnonymizedSpanCount := &view.View{
+		Name:        statAnonymizedSpanCount.Name(),
+		Description: statAnonymizedSpanCount.Description(),
+		Measure:     statAnonymizedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	return []*view.View{
+		viewAnonymizedSpanCount,
+	}
+}
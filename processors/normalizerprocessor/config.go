@@ -0,0 +1,26 @@
+package normalizerprocessor
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for normalizer processor.
+// The processor renames legacy Zipkin, OpenCensus and Jaeger attributes to
+// the OpenTelemetry semantic conventions and fills in the span kind, the
+// protocol and the gRPC method and status when the receiver did not provide them.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// InferSpanKind sets the kind of spans with an unspecified kind from the
+	// span.kind tag or the attributes of the span. Default true.
+	InferSpanKind bool `mapstructure:"infer_span_kind"`
+	// InferProtocol sets the span.protocol attribute from the attributes of the span. Default true.
+	InferProtocol bool `mapstructure:"infer_protocol"`
+	// InferRPC sets the rpc.system, rpc.service, rpc.method and rpc.grpc.status_code
+	// attributes of gRPC spans from the span name and legacy tags. Default true.
+	InferRPC bool `mapstructure:"infer_rpc"`
+}
+
+var _ config.Processor = (*Config)(nil)
@@ -0,0 +1,116 @@
+package normalizerprocessor
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// spanKindAttributeKey is the Jaeger and OpenCensus tag holding the span kind.
+	spanKindAttributeKey = "span.kind"
+	// protocolAttributeKey holds the inferred protocol of the span.
+	protocolAttributeKey = "span.protocol"
+
+	protocolHTTP  = "http"
+	protocolHTTPS = "https"
+	protocolGRPC  = "grpc"
+
+	// componentAttributeKey is the OpenTracing tag naming the instrumentation.
+	componentAttributeKey = "component"
+	// rpcGRPCStatusCodeAttributeKey holds the numeric gRPC status code.
+	rpcGRPCStatusCodeAttributeKey = "rpc.grpc.status_code"
+)
+
+type rename struct {
+	from string
+	to   string
+}
+
+// renames maps legacy attribute names to the semantic conventions. When several
+// legacy names map to the same attribute the first one present wins.
+var renames = []rename{
+	// Zipkin and OpenCensus
+	{from: "http.path", to: conventions.AttributeHTTPTarget},
+	{from: "http.status", to: conventions.AttributeHTTPStatusCode},
+	{from: "http.request.size", to: conventions.AttributeHTTPRequestContentLength},
+	{from: "http.response.size", to: conventions.AttributeHTTPResponseContentLength},
+	// OpenCensus with the legacy Stackdriver names
+	{from: "/http/path", to: conventions.AttributeHTTPTarget},
+	{from: "/http/status_code", to: conventions.AttributeHTTPStatusCode},
+	{from: "/http/method", to: conventions.AttributeHTTPMethod},
+	{from: "/http/host", to: conventions.AttributeHTTPHost},
+	{from: "/http/url", to: conventions.AttributeHTTPURL},
+	{from: "/http/user_agent", to: conventions.AttributeHTTPUserAgent},
+	{from: "/http/route", to: conventions.AttributeHTTPRoute},
+	{from: "/http/request/size", to: conventions.AttributeHTTPRequestContentLength},
+	{from: "/http/response/size", to: conventions.AttributeHTTPResponseContentLength},
+	// Jaeger and OpenTracing
+	{from: "peer.hostname", to: conventions.AttributeNetPeerName},
+	{from: "peer.ipv4", to: conventions.AttributeNetPeerIP},
+	{from: "peer.ipv6", to: conventions.AttributeNetPeerIP},
+	{from: "peer.port", to: conventions.AttributeNetPeerPort},
+	{from: "db.type", to: conventions.AttributeDBSystem},
+	{from: "db.instance", to: conventions.AttributeDBName},
+	{from: "message_bus.destination", to: conventions.AttributeMessagingDestination},
+}
+
+// intAttributes are the semantic convention attributes with an int value,
+// legacy instrumentations often report them as strings.
+var intAttributes = []string{
+	conventions.AttributeHTTPStatusCode,
+	conventions.AttributeHTTPRequestContentLength,
+	conventions.AttributeHTTPResponseContentLength,
+	conventions.AttributeNetPeerPort,
+}
+
+// spanKinds maps the values of the span.kind tag to the span kind.
+var spanKinds = map[string]pdata.SpanKind{
+	"client":   pdata.SpanKindClient,
+	"server":   pdata.SpanKindServer,
+	"producer": pdata.SpanKindProducer,
+	"consumer": pdata.SpanKindConsumer,
+	"internal": pdata.SpanKindInternal,
+}
+
+// grpcMethodAttributes are legacy tags holding the full gRPC method,
+// e.g. /helloworld.Greeter/SayHello.
+var grpcMethodAttributes = []string{
+	"grpc.method",
+	"grpc.method_name",
+	"grpc.path",
+}
+
+// grpcStatusAttributes are legacy tags holding the gRPC status as a number or a name.
+var grpcStatusAttributes = []string{
+	"grpc.status_code",
+	"grpc.status",
+	"grpc.code",
+}
+
+// openCensusGRPCPrefixes prefix the names of OpenCensus gRPC spans, e.g.
+// Sent.helloworld.Greeter.SayHello.
+var openCensusGRPCPrefixes = []string{"Sent.", "Recv."}
+
+// grpcStatusCodes maps the upper case gRPC status names without underscores to
+// the status code, so that both NOT_FOUND and NotFound are recognized.
+var grpcStatusCodes = map[string]codes.Code{
+	"OK":                 codes.OK,
+	"CANCELLED":          codes.Canceled,
+	"CANCELED":           codes.Canceled,
+	"UNKNOWN":            codes.Unknown,
+	"INVALIDARGUMENT":    codes.InvalidArgument,
+	"DEADLINEEXCEEDED":   codes.DeadlineExceeded,
+	"NOTFOUND":           codes.NotFound,
+	"ALREADYEXISTS":      codes.AlreadyExists,
+	"PERMISSIONDENIED":   codes.PermissionDenied,
+	"RESOURCEEXHAUSTED":  codes.ResourceExhausted,
+	"FAILEDPRECONDITION": codes.FailedPrecondition,
+	"ABORTED":            codes.Aborted,
+	"OUTOFRANGE":         codes.OutOfRange,
+	"UNIMPLEMENTED":      codes.Unimplemented,
+	"INTERNAL":           codes.Internal,
+	"UNAVAILABLE":        codes.Unavailable,
+	"DATALOSS":           codes.DataLoss,
+	"UNAUTHENTICATED":    codes.Unauthenticated,
+}
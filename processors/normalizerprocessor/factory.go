@@ -0,0 +1,55 @@
+package normalizerprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_normalizer"
+	defaultTenantIDAttributeKey = "tenant-id"
+)
+
+// NewFactory creates a factory for the normalizer processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		InferSpanKind:        true,
+		InferProtocol:        true,
+		InferRPC:             true,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	pCfg := cfg.(*Config)
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		&processor{
+			tenantIDAttributeKey: pCfg.TenantIDAttributeKey,
+			inferSpanKind:        pCfg.InferSpanKind,
+			inferProtocol:        pCfg.InferProtocol,
+			inferRPC:             pCfg.InferRPC,
+		})
+}
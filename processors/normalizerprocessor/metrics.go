@@ -0,0 +1,37 @@
+package normalizerprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagChange   = tag.MustNewKey("change")
+
+	statNormalizedSpanCount = stats.Int64("normalizer_normalized_span_count", "Number of spans changed by the normalizer", stats.UnitDimensionless)
+)
+
+// Changes applied to a span.
+const (
+	changeAttributes = "attributes"
+	changeKind       = "kind"
+	changeProtocol   = "protocol"
+	changeRPC        = "rpc"
+)
+
+// MetricViews returns the metrics views for normalizer processor.
+func MetricViews() []*view.View {
+	viewNormalizedSpanCount := &view.View{
+		Name:        statNormalizedSpanCount.Name(),
+		Description: statNormalizedSpanCount.Description(),
+		Measure:     statNormalizedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagChange},
+	}
+
+	return []*view.View{
+		viewNormalizedSpanCount,
+	}
+}
@@ -0,0 +1,357 @@
+package normalizerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"google.golang.org/grpc/codes"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+	inferSpanKind        bool
+	inferProtocol        bool
+	inferRPC             bool
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+// changeCounts counts changed spans per tenant and change.
+type changeCounts map[string]map[string]int64
+
+func (c changeCounts) inc(tenantID, change string) {
+	if _, ok := c[tenantID]; !ok {
+		c[tenantID] = map[string]int64{}
+	}
+	c[tenantID][change]++
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	changes := changeCounts{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				if normalizeAttributes(span.Attributes()) {
+					changes.inc(tenantID, changeAttributes)
+				}
+				if p.inferSpanKind && inferSpanKind(span) {
+					changes.inc(tenantID, changeKind)
+				}
+				if p.inferRPC && inferRPC(span) {
+					changes.inc(tenantID, changeRPC)
+				}
+				if p.inferProtocol && inferProtocol(span.Attributes()) {
+					changes.inc(tenantID, changeProtocol)
+				}
+			}
+		}
+	}
+
+	for tenantID, counts := range changes {
+		for change, count := range counts {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagChange, change))
+			stats.Record(tCtx, statNormalizedSpanCount.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+// normalizeAttributes renames legacy attributes and converts values to the
+// types of the semantic conventions. It returns true if an attribute changed.
+func normalizeAttributes(attrs pdata.AttributeMap) bool {
+	changed := false
+	for _, r := range renames {
+		v, ok := attrs.Get(r.from)
+		if !ok {
+			continue
+		}
+		if _, exists := attrs.Get(r.to); !exists {
+			if r.to == conventions.AttributeNetPeerIP && v.Type() == pdata.AttributeValueTypeInt {
+				attrs.InsertString(r.to, ipv4FromInt(v.IntVal()))
+			} else {
+				attrs.Insert(r.to, v)
+			}
+		}
+		attrs.Delete(r.from)
+		changed = true
+	}
+
+	for _, key := range intAttributes {
+		v, ok := attrs.Get(key)
+		if !ok {
+			continue
+		}
+		switch v.Type() {
+		case pdata.AttributeValueTypeString:
+			if i, err := strconv.ParseInt(strings.TrimSpace(v.StringVal()), 10, 64); err == nil {
+				attrs.UpsertInt(key, i)
+				changed = true
+			}
+		case pdata.AttributeValueTypeDouble:
+			attrs.UpsertInt(key, int64(v.DoubleVal()))
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ipv4FromInt converts the Jaeger integer representation of an IPv4 address.
+func ipv4FromInt(i int64) string {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(i))
+	return ip.String()
+}
+
+// inferSpanKind sets the kind of a span with unspecified kind and removes the
+// span.kind tag. It returns true if the kind was set.
+func inferSpanKind(span pdata.Span) bool {
+	attrs := span.Attributes()
+	kind := span.Kind()
+	if tagValue, ok := attrs.Get(spanKindAttributeKey); ok {
+		if k, ok := spanKinds[strings.ToLower(tagValue.StringVal())]; ok && kind == pdata.SpanKindUnspecified {
+			kind = k
+		}
+		attrs.Delete(spanKindAttributeKey)
+	}
+	if kind == pdata.SpanKindUnspecified {
+		kind = kindFromAttributes(attrs)
+	}
+
+	if kind == span.Kind() {
+		return false
+	}
+	span.SetKind(kind)
+	return true
+}
+
+// kindFromAttributes guesses the kind from the attributes instrumentations usually set.
+// Spans without any remote call attributes are internal.
+func kindFromAttributes(attrs pdata.AttributeMap) pdata.SpanKind {
+	if op, ok := attrs.Get(conventions.AttributeMessagingOperation); ok {
+		if op := op.StringVal(); op == "receive" || op == "process" {
+			return pdata.SpanKindConsumer
+		}
+	}
+	switch {
+	case has(attrs, conventions.AttributeMessagingDestination):
+		return pdata.SpanKindProducer
+	case has(attrs, conventions.AttributeHTTPURL), has(attrs, conventions.AttributeDBSystem), has(attrs, conventions.AttributePeerService):
+		return pdata.SpanKindClient
+	case has(attrs, conventions.AttributeHTTPTarget), has(attrs, conventions.AttributeHTTPRoute):
+		return pdata.SpanKindServer
+	case has(attrs, conventions.AttributeHTTPMethod), has(attrs, conventions.AttributeRPCSystem),
+		has(attrs, conventions.AttributeNetPeerIP), has(attrs, conventions.AttributeNetPeerName):
+		// A remote call of unknown direction.
+		return pdata.SpanKindUnspecified
+	}
+	return pdata.SpanKindInternal
+}
+
+// inferProtocol sets the span.protocol attribute if it is missing and returns true if it was set.
+func inferProtocol(attrs pdata.AttributeMap) bool {
+	if has(attrs, protocolAttributeKey) {
+		return false
+	}
+
+	protocol := ""
+	if isGRPC(attrs) {
+		protocol = protocolGRPC
+	} else if has(attrs, conventions.AttributeHTTPMethod) || has(attrs, conventions.AttributeHTTPURL) ||
+		has(attrs, conventions.AttributeHTTPTarget) || has(attrs, conventions.AttributeHTTPStatusCode) {
+		protocol = protocolHTTP
+		scheme, _ := attrs.Get(conventions.AttributeHTTPScheme)
+		url, _ := attrs.Get(conventions.AttributeHTTPURL)
+		if scheme.StringVal() == protocolHTTPS || strings.HasPrefix(url.StringVal(), "https://") {
+			protocol = protocolHTTPS
+		}
+	}
+
+	if protocol == "" {
+		return false
+	}
+	attrs.InsertString(protocolAttributeKey, protocol)
+	return true
+}
+
+func isGRPC(attrs pdata.AttributeMap) bool {
+	if rpcSystem, ok := attrs.Get(conventions.AttributeRPCSystem); ok {
+		return rpcSystem.StringVal() == protocolGRPC
+	}
+	grpc := false
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		grpc = strings.HasPrefix(k, "grpc.") || strings.HasPrefix(k, "rpc.grpc.")
+		return !grpc
+	})
+	return grpc
+}
+
+// isGRPCSpan returns true if the attributes, the OpenTracing component or the
+// OpenCensus span name identify a gRPC span.
+func isGRPCSpan(span pdata.Span) bool {
+	if isGRPC(span.Attributes()) {
+		return true
+	}
+	if component, ok := span.Attributes().Get(componentAttributeKey); ok &&
+		strings.Contains(strings.ToLower(component.StringVal()), protocolGRPC) {
+		return true
+	}
+	for _, prefix := range openCensusGRPCPrefixes {
+		if strings.HasPrefix(span.Name(), prefix) {
+			_, _, ok := splitGRPCMethod(span.Name())
+			return ok
+		}
+	}
+	return false
+}
+
+// splitGRPCMethod splits a full gRPC method, either /package.Service/Method,
+// package.Service/Method or an OpenCensus span name, into service and method.
+func splitGRPCMethod(fullMethod string) (string, string, bool) {
+	if strings.ContainsAny(fullMethod, " ?") {
+		return "", "", false
+	}
+	for _, prefix := range openCensusGRPCPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			name := strings.TrimPrefix(fullMethod, prefix)
+			i := strings.LastIndexByte(name, '.')
+			if i <= 0 || i == len(name)-1 || strings.Contains(name, "/") {
+				return "", "", false
+			}
+			return name[:i], name[i+1:], true
+		}
+	}
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// parseGRPCStatus returns the status code of a numeric or named status.
+func parseGRPCStatus(v pdata.AttributeValue) (codes.Code, bool) {
+	switch v.Type() {
+	case pdata.AttributeValueTypeInt:
+		return codes.Code(v.IntVal()), true
+	case pdata.AttributeValueTypeDouble:
+		return codes.Code(v.DoubleVal()), true
+	case pdata.AttributeValueTypeString:
+		s := strings.TrimSpace(v.StringVal())
+		if i, err := strconv.ParseUint(s, 10, 32); err == nil {
+			return codes.Code(i), true
+		}
+		code, ok := grpcStatusCodes[strings.ToUpper(strings.ReplaceAll(s, "_", ""))]
+		return code, ok
+	}
+	return 0, false
+}
+
+// urlPath returns the path of the http.target or http.url attribute.
+func urlPath(attrs pdata.AttributeMap) string {
+	if target, ok := attrs.Get(conventions.AttributeHTTPTarget); ok {
+		return target.StringVal()
+	}
+	if u, ok := attrs.Get(conventions.AttributeHTTPURL); ok {
+		if parsed, err := url.Parse(u.StringVal()); err == nil {
+			return parsed.Path
+		}
+	}
+	return ""
+}
+
+// insertString inserts the attribute if it is missing and returns true if it was inserted.
+func insertString(attrs pdata.AttributeMap, key, value string) bool {
+	if has(attrs, key) {
+		return false
+	}
+	attrs.InsertString(key, value)
+	return true
+}
+
+func has(attrs pdata.AttributeMap, key string) bool {
+	_, ok := attrs.Get(key)
+	return ok
+}
+
+// inferRPC sets the rpc.* attributes of gRPC spans reported with legacy tags
+// and removes the legacy method and status tags. It returns true if an attribute changed.
+func inferRPC(span pdata.Span) bool {
+	attrs := span.Attributes()
+	if !isGRPCSpan(span) {
+		return false
+	}
+
+	changed := false
+	if !has(attrs, conventions.AttributeRPCSystem) {
+		attrs.InsertString(conventions.AttributeRPCSystem, protocolGRPC)
+		changed = true
+	}
+
+	fullMethod := ""
+	for _, key := range grpcMethodAttributes {
+		if v, ok := attrs.Get(key); ok {
+			if fullMethod == "" {
+				fullMethod = v.StringVal()
+			}
+			attrs.Delete(key)
+			changed = true
+		}
+	}
+	if !has(attrs, conventions.AttributeRPCService) || !has(attrs, conventions.AttributeRPCMethod) {
+		for _, candidate := range []string{fullMethod, span.Name(), urlPath(attrs)} {
+			if service, method, ok := splitGRPCMethod(candidate); ok {
+				changed = insertString(attrs, conventions.AttributeRPCService, service) || changed
+				changed = insertString(attrs, conventions.AttributeRPCMethod, method) || changed
+				break
+			}
+		}
+	}
+
+	if v, ok := attrs.Get(rpcGRPCStatusCodeAttributeKey); ok && v.Type() != pdata.AttributeValueTypeInt {
+		if code, ok := parseGRPCStatus(v); ok {
+			attrs.UpsertInt(rpcGRPCStatusCodeAttributeKey, int64(code))
+			changed = true
+		}
+	}
+	for _, key := range grpcStatusAttributes {
+		v, ok := attrs.Get(key)
+		if !ok {
+			continue
+		}
+		code, ok := parseGRPCStatus(v)
+		if !ok {
+			continue
+		}
+		if !has(attrs, rpcGRPCStatusCodeAttributeKey) {
+			attrs.InsertInt(rpcGRPCStatusCodeAttributeKey, int64(code))
+		}
+		attrs.Delete(key)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,289 @@
+package normalizerprocessor
+
+import (
+	"context"
+	"testing"
+
+	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/trace/jaeger"
+)
+
+func newTestProcessor() *processor {
+	return &processor{
+		tenantIDAttributeKey: defaultTenantIDAttributeKey,
+		inferSpanKind:        true,
+		inferProtocol:        true,
+		inferRPC:             true,
+	}
+}
+
+func normalize(t *testing.T, kind pdata.SpanKind, attrs map[string]pdata.AttributeValue) pdata.Span {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetKind(kind)
+	pdata.NewAttributeMap().InitFromMap(attrs).CopyTo(span.Attributes())
+
+	td, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+func TestNormalizeZipkinHTTP(t *testing.T) {
+	span := normalize(t, pdata.SpanKindServer, map[string]pdata.AttributeValue{
+		"http.path":   pdata.NewAttributeValueString("/api/v1/users"),
+		"http.status": pdata.NewAttributeValueString("404"),
+		"http.method": pdata.NewAttributeValueString("GET"),
+	})
+
+	assert.Equal(t, pdata.SpanKindServer, span.Kind())
+	assert.Equal(t, map[string]interface{}{
+		"http.target":      "/api/v1/users",
+		"http.status_code": int64(404),
+		"http.method":      "GET",
+		"span.protocol":    "http",
+	}, attributesAsMap(span.Attributes()))
+}
+
+func TestNormalizeOpenCensusHTTP(t *testing.T) {
+	span := normalize(t, pdata.SpanKindUnspecified, map[string]pdata.AttributeValue{
+		"/http/url":         pdata.NewAttributeValueString("https://example.com/users"),
+		"/http/status_code": pdata.NewAttributeValueInt(200),
+		"/http/method":      pdata.NewAttributeValueString("POST"),
+	})
+
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, map[string]interface{}{
+		"http.url":         "https://example.com/users",
+		"http.status_code": int64(200),
+		"http.method":      "POST",
+		"span.protocol":    "https",
+	}, attributesAsMap(span.Attributes()))
+}
+
+func TestNormalizeJaeger(t *testing.T) {
+	span := normalize(t, pdata.SpanKindUnspecified, map[string]pdata.AttributeValue{
+		"span.kind":   pdata.NewAttributeValueString("server"),
+		"peer.ipv4":   pdata.NewAttributeValueInt(0x7f000001),
+		"peer.port":   pdata.NewAttributeValueString("8080"),
+		"db.type":     pdata.NewAttributeValueString("mysql"),
+		"db.instance": pdata.NewAttributeValueString("customers"),
+	})
+
+	assert.Equal(t, pdata.SpanKindServer, span.Kind())
+	assert.Equal(t, map[string]interface{}{
+		"net.peer.ip":   "127.0.0.1",
+		"net.peer.port": int64(8080),
+		"db.system":     "mysql",
+		"db.name":       "customers",
+	}, attributesAsMap(span.Attributes()))
+}
+
+func TestExistingAttributeWins(t *testing.T) {
+	span := normalize(t, pdata.SpanKindServer, map[string]pdata.AttributeValue{
+		"http.path":   pdata.NewAttributeValueString("/legacy"),
+		"http.target": pdata.NewAttributeValueString("/current"),
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"http.target":   "/current",
+		"span.protocol": "http",
+	}, attributesAsMap(span.Attributes()))
+}
+
+func TestInferSpanKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrs    map[string]pdata.AttributeValue
+		expected pdata.SpanKind
+	}{
+		{
+			name:     "local",
+			expected: pdata.SpanKindInternal,
+		},
+		{
+			name:     "db",
+			attrs:    map[string]pdata.AttributeValue{"db.system": pdata.NewAttributeValueString("redis")},
+			expected: pdata.SpanKindClient,
+		},
+		{
+			name:     "consumer",
+			attrs:    map[string]pdata.AttributeValue{"messaging.operation": pdata.NewAttributeValueString("receive")},
+			expected: pdata.SpanKindConsumer,
+		},
+		{
+			name:     "producer",
+			attrs:    map[string]pdata.AttributeValue{"message_bus.destination": pdata.NewAttributeValueString("orders")},
+			expected: pdata.SpanKindProducer,
+		},
+		{
+			name:     "unknown direction",
+			attrs:    map[string]pdata.AttributeValue{"http.method": pdata.NewAttributeValueString("GET")},
+			expected: pdata.SpanKindUnspecified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := normalize(t, pdata.SpanKindUnspecified, tt.attrs)
+			assert.Equal(t, tt.expected, span.Kind())
+		})
+	}
+}
+
+func TestInferGRPC(t *testing.T) {
+	span := normalize(t, pdata.SpanKindClient, map[string]pdata.AttributeValue{
+		"grpc.status_code": pdata.NewAttributeValueInt(0),
+		"http.url":         pdata.NewAttributeValueString("https://example.com/api.Users/Get"),
+	})
+
+	protocol, ok := span.Attributes().Get(protocolAttributeKey)
+	require.True(t, ok)
+	assert.Equal(t, protocolGRPC, protocol.StringVal())
+}
+
+func TestInferRPC(t *testing.T) {
+	tests := []struct {
+		name     string
+		spanName string
+		attrs    map[string]pdata.AttributeValue
+		expected map[string]interface{}
+	}{
+		{
+			name:     "opentracing",
+			spanName: "/helloworld.Greeter/SayHello",
+			attrs: map[string]pdata.AttributeValue{
+				"component":        pdata.NewAttributeValueString("gRPC"),
+				"grpc.status_code": pdata.NewAttributeValueString("NOT_FOUND"),
+			},
+			expected: map[string]interface{}{
+				"component":            "gRPC",
+				"rpc.system":           "grpc",
+				"rpc.service":          "helloworld.Greeter",
+				"rpc.method":           "SayHello",
+				"rpc.grpc.status_code": int64(5),
+				"span.protocol":        "grpc",
+			},
+		},
+		{
+			name:     "legacy method tag",
+			spanName: "SayHello",
+			attrs: map[string]pdata.AttributeValue{
+				"grpc.method": pdata.NewAttributeValueString("/helloworld.Greeter/SayHello"),
+				"grpc.status": pdata.NewAttributeValueString("Unavailable"),
+			},
+			expected: map[string]interface{}{
+				"rpc.system":           "grpc",
+				"rpc.service":          "helloworld.Greeter",
+				"rpc.method":           "SayHello",
+				"rpc.grpc.status_code": int64(14),
+				"span.protocol":        "grpc",
+			},
+		},
+		{
+			name:     "opencensus",
+			spanName: "Recv.helloworld.Greeter.SayHello",
+			attrs:    map[string]pdata.AttributeValue{},
+			expected: map[string]interface{}{
+				"rpc.system":    "grpc",
+				"rpc.service":   "helloworld.Greeter",
+				"rpc.method":    "SayHello",
+				"span.protocol": "grpc",
+			},
+		},
+		{
+			name:     "existing attributes win",
+			spanName: "/other.Service/Other",
+			attrs: map[string]pdata.AttributeValue{
+				"rpc.system":           pdata.NewAttributeValueString("grpc"),
+				"rpc.service":          pdata.NewAttributeValueString("helloworld.Greeter"),
+				"rpc.method":           pdata.NewAttributeValueString("SayHello"),
+				"rpc.grpc.status_code": pdata.NewAttributeValueString("2"),
+				"grpc.status_code":     pdata.NewAttributeValueInt(0),
+			},
+			expected: map[string]interface{}{
+				"rpc.system":           "grpc",
+				"rpc.service":          "helloworld.Greeter",
+				"rpc.method":           "SayHello",
+				"rpc.grpc.status_code": int64(2),
+				"span.protocol":        "grpc",
+			},
+		},
+		{
+			name:     "not grpc",
+			spanName: "/api/users",
+			attrs: map[string]pdata.AttributeValue{
+				"component": pdata.NewAttributeValueString("net/http"),
+			},
+			expected: map[string]interface{}{
+				"component": "net/http",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := pdata.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName(tt.spanName)
+			span.SetKind(pdata.SpanKindClient)
+			pdata.NewAttributeMap().InitFromMap(tt.attrs).CopyTo(span.Attributes())
+
+			td, err := newTestProcessor().ProcessTraces(context.Background(), td)
+			require.NoError(t, err)
+			span = td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expected, attributesAsMap(span.Attributes()))
+		})
+	}
+}
+
+func TestInferRPCFromJaegerThrift(t *testing.T) {
+	statusCode := int64(14)
+	batch := &jaegerthrift.Batch{
+		Process: &jaegerthrift.Process{ServiceName: "frontend"},
+		Spans: []*jaegerthrift.Span{
+			{
+				TraceIdLow:    1,
+				SpanId:        2,
+				OperationName: "helloworld.Greeter/SayHello",
+				Tags: []*jaegerthrift.Tag{
+					{Key: "span.kind", VType: jaegerthrift.TagType_STRING, VStr: stringPtr("client")},
+					{Key: "component", VType: jaegerthrift.TagType_STRING, VStr: stringPtr("java-grpc")},
+					{Key: "grpc.status", VType: jaegerthrift.TagType_LONG, VLong: &statusCode},
+				},
+			},
+		},
+	}
+
+	td, err := newTestProcessor().ProcessTraces(context.Background(), jaeger.ThriftBatchToInternalTraces(batch))
+	require.NoError(t, err)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, map[string]interface{}{
+		"component":            "java-grpc",
+		"rpc.system":           "grpc",
+		"rpc.service":          "helloworld.Greeter",
+		"rpc.method":           "SayHello",
+		"rpc.grpc.status_code": int64(14),
+		"span.protocol":        "grpc",
+	}, attributesAsMap(span.Attributes()))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]interface{} {
+	result := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			result[k] = v.IntVal()
+		default:
+			result[k] = v.StringVal()
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,89 @@
+package prioritylaneprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for priority lane processor.
+// The processor queues the incoming batches in lanes and sends them to the
+// next consumer asynchronously, dequeuing the lanes in proportion to their
+// weights so that critical telemetry is not stuck behind bulk traffic.
+// The queued batches are sent with the gRPC metadata and the receiver tags of
+// the incoming request, but not with its deadline.
+//
+// A batch is classified into the first lane in configuration order matching
+// it, a lane matches a batch containing a span or resource of one of its
+// tenants or, when error_spans is set, a span with an error status. Batches
+// not matching any lane are queued in the default lane:
+//
+//	default_lane_weight: 1
+//	lanes:
+//	  - name: errors
+//	    weight: 8
+//	    error_spans: true
+//	  - name: premium
+//	    weight: 4
+//	    tenants: [acme]
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute and resource attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Lanes are the lanes in classification order.
+	Lanes []LaneConfig `mapstructure:"lanes"`
+	// DefaultLaneWeight is the weight of the default lane. Default 1.
+	DefaultLaneWeight int `mapstructure:"default_lane_weight"`
+	// QueueSize is the maximum number of batches queued in each lane, batches
+	// are refused with a retryable error when the lane is full. Default 1000.
+	QueueSize int `mapstructure:"queue_size"`
+	// NumWorkers is the number of workers sending the batches to the next consumer. Default 2.
+	NumWorkers int `mapstructure:"num_workers"`
+}
+
+// LaneConfig defines a lane and the batches classified into it.
+type LaneConfig struct {
+	// Name identifies the lane in the metrics.
+	Name string `mapstructure:"name"`
+	// Weight is the share of the dequeued batches taken from the lane relative
+	// to the other lanes with queued batches.
+	Weight int `mapstructure:"weight"`
+	// Tenants are the tenant IDs of the batches classified into the lane.
+	Tenants []string `mapstructure:"tenants"`
+	// ErrorSpans classifies the batches containing a span with an error status into the lane.
+	ErrorSpans bool `mapstructure:"error_spans"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.DefaultLaneWeight <= 0 {
+		return fmt.Errorf("default_lane_weight must be greater than 0, got %d", cfg.DefaultLaneWeight)
+	}
+	if cfg.QueueSize <= 0 {
+		return fmt.Errorf("queue_size must be greater than 0, got %d", cfg.QueueSize)
+	}
+	if cfg.NumWorkers <= 0 {
+		return fmt.Errorf("num_workers must be greater than 0, got %d", cfg.NumWorkers)
+	}
+	names := map[string]bool{defaultLaneName: true}
+	for _, lane := range cfg.Lanes {
+		if lane.Name == "" {
+			return errors.New("lane name is required")
+		}
+		if names[lane.Name] {
+			return fmt.Errorf("duplicate lane name %q", lane.Name)
+		}
+		names[lane.Name] = true
+		if lane.Weight <= 0 {
+			return fmt.Errorf("weight of lane %q must be greater than 0, got %d", lane.Name, lane.Weight)
+		}
+		if len(lane.Tenants) == 0 && !lane.ErrorSpans {
+			return fmt.Errorf("lane %q must have tenants or error_spans", lane.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package prioritylaneprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	pCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, pCfg.TenantIDAttributeKey)
+	assert.Equal(t, 2, pCfg.DefaultLaneWeight)
+	assert.Equal(t, 500, pCfg.QueueSize)
+	assert.Equal(t, 4, pCfg.NumWorkers)
+	assert.Equal(t, []LaneConfig{
+		{Name: "errors", Weight: 8, ErrorSpans: true},
+		{Name: "premium", Weight: 4, Tenants: []string{"jdoe", "acme"}},
+	}, pCfg.Lanes)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"default lane weight", func(cfg *Config) { cfg.DefaultLaneWeight = 0 }},
+		{"queue size", func(cfg *Config) { cfg.QueueSize = 0 }},
+		{"workers", func(cfg *Config) { cfg.NumWorkers = 0 }},
+		{"lane name", func(cfg *Config) { cfg.Lanes[0].Name = "" }},
+		{"default lane name", func(cfg *Config) { cfg.Lanes[0].Name = defaultLaneName }},
+		{"duplicate lane name", func(cfg *Config) { cfg.Lanes[1].Name = cfg.Lanes[0].Name }},
+		{"lane weight", func(cfg *Config) { cfg.Lanes[1].Weight = -1 }},
+		{"lane match", func(cfg *Config) { cfg.Lanes[1].Tenants = nil }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Lanes = []LaneConfig{
+				{Name: "errors", Weight: 8, ErrorSpans: true},
+				{Name: "premium", Weight: 4, Tenants: []string{"jdoe"}},
+			}
+			require.NoError(t, cfg.Validate())
+			test.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
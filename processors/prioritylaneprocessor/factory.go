@@ -0,0 +1,63 @@
+package prioritylaneprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_prioritylane"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultDefaultLaneWeight    = 1
+	defaultQueueSize            = 1000
+	defaultNumWorkers           = 2
+)
+
+// NewFactory creates a factory for the priority lane processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		DefaultLaneWeight:    defaultDefaultLaneWeight,
+		QueueSize:            defaultQueueSize,
+		NumWorkers:           defaultNumWorkers,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	p := newProcessor(params.Logger, cfg.(*Config))
+	p.nextTraces = nextConsumer
+	return p, nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	p := newProcessor(params.Logger, cfg.(*Config))
+	p.nextMetrics = nextConsumer
+	return p, nil
+}
@@ -0,0 +1,48 @@
+package prioritylaneprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagLane = tag.MustNewKey("lane")
+
+	statQueueDepth        = stats.Int64("priority_lane_queue_depth", "Number of batches queued in the lane", stats.UnitDimensionless)
+	statWaitTime          = stats.Float64("priority_lane_wait_time", "Time a batch waited in the lane before being sent", stats.UnitMilliseconds)
+	statRefusedBatchCount = stats.Int64("priority_lane_refused_batch_count", "Number of batches refused because the lane was full", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for priority lane processor.
+func MetricViews() []*view.View {
+	viewQueueDepth := &view.View{
+		Name:        statQueueDepth.Name(),
+		Description: statQueueDepth.Description(),
+		Measure:     statQueueDepth,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{tagLane},
+	}
+
+	viewWaitTime := &view.View{
+		Name:        statWaitTime.Name(),
+		Description: statWaitTime.Description(),
+		Measure:     statWaitTime,
+		Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000),
+		TagKeys:     []tag.Key{tagLane},
+	}
+
+	viewRefusedBatchCount := &view.View{
+		Name:        statRefusedBatchCount.Name(),
+		Description: statRefusedBatchCount.Description(),
+		Measure:     statRefusedBatchCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagLane},
+	}
+
+	return []*view.View{
+		viewQueueDepth,
+		viewWaitTime,
+		viewRefusedBatchCount,
+	}
+}
@@ -0,0 +1,294 @@
+package prioritylaneprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// defaultLaneName identifies the default lane in the metrics.
+const defaultLaneName = "default"
+
+var errShutdown = errors.New("priority lane processor is shut down")
+
+// item is a queued batch.
+type item struct {
+	enqueued time.Time
+	consume  func(context.Context) error
+	// metadata and tags are taken from the context of the incoming batch, so
+	// the next consumers see the gRPC metadata and the receiver tags.
+	metadata metadata.MD
+	tags     *tag.Map
+}
+
+// context returns a context carrying the metadata and the tags of the incoming batch.
+func (it item) context() context.Context {
+	ctx := context.Background()
+	if it.metadata != nil {
+		ctx = metadata.NewIncomingContext(ctx, it.metadata)
+	}
+	if it.tags != nil {
+		ctx = tag.NewContext(ctx, it.tags)
+	}
+	return ctx
+}
+
+type lane struct {
+	name       string
+	weight     int
+	tenants    map[string]bool
+	errorSpans bool
+
+	items []item
+	// current is the smooth weighted round robin state of the lane.
+	current int
+}
+
+func (l *lane) matches(tenants map[string]bool, hasErrorSpan bool) bool {
+	if l.errorSpans && hasErrorSpan {
+		return true
+	}
+	for tenantID := range tenants {
+		if l.tenants[tenantID] {
+			return true
+		}
+	}
+	return false
+}
+
+type processor struct {
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	queueSize            int
+	numWorkers           int
+	nextTraces           consumer.Traces
+	nextMetrics          consumer.Metrics
+
+	// lanes are the configured lanes in classification order followed by the default lane.
+	lanes       []*lane
+	defaultLane *lane
+
+	// mu guards the lane queues and stopped, cond signals queued batches and stopping.
+	mu      sync.Mutex
+	cond    *sync.Cond
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+var _ component.MetricsProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config) *processor {
+	p := &processor{
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		queueSize:            cfg.QueueSize,
+		numWorkers:           cfg.NumWorkers,
+		defaultLane:          &lane{name: defaultLaneName, weight: cfg.DefaultLaneWeight},
+	}
+	p.cond = sync.NewCond(&p.mu)
+	for _, l := range cfg.Lanes {
+		tenants := map[string]bool{}
+		for _, tenantID := range l.Tenants {
+			tenants[tenantID] = true
+		}
+		p.lanes = append(p.lanes, &lane{
+			name:       l.Name,
+			weight:     l.Weight,
+			tenants:    tenants,
+			errorSpans: l.ErrorSpans,
+		})
+	}
+	p.lanes = append(p.lanes, p.defaultLane)
+	return p
+}
+
+// Start starts the workers sending the queued batches.
+func (p *processor) Start(context.Context, component.Host) error {
+	for i := 0; i < p.numWorkers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return nil
+}
+
+// Shutdown stops accepting batches and waits until the queued batches are
+// sent or ctx is done. The workers keep sending the queued batches after ctx is done.
+func (p *processor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.stopped = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Capabilities implements consumer.Traces and consumer.Metrics
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces queues the traces in the lane of their tenants and span status.
+func (p *processor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	tenants := map[string]bool{}
+	hasErrorSpan := false
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID := p.resourceTenantID(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenants[attr.StringVal()] = true
+				} else {
+					tenants[resourceTenantID] = true
+				}
+				if span.Status().Code() == pdata.StatusCodeError {
+					hasErrorSpan = true
+				}
+			}
+		}
+	}
+
+	return p.enqueue(ctx, p.classify(tenants, hasErrorSpan), func(ctx context.Context) error {
+		return p.nextTraces.ConsumeTraces(ctx, td)
+	})
+}
+
+// ConsumeMetrics queues the metrics in the lane of their tenants.
+func (p *processor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	tenants := map[string]bool{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		tenants[p.resourceTenantID(rms.At(i).Resource())] = true
+	}
+
+	return p.enqueue(ctx, p.classify(tenants, false), func(ctx context.Context) error {
+		return p.nextMetrics.ConsumeMetrics(ctx, md)
+	})
+}
+
+func (p *processor) resourceTenantID(resource pdata.Resource) string {
+	if attr, ok := resource.Attributes().Get(p.tenantIDAttributeKey); ok {
+		return attr.StringVal()
+	}
+	return ""
+}
+
+func (p *processor) classify(tenants map[string]bool, hasErrorSpan bool) *lane {
+	for _, l := range p.lanes {
+		if l.matches(tenants, hasErrorSpan) {
+			return l
+		}
+	}
+	return p.defaultLane
+}
+
+// enqueue queues the batch or returns a retryable error when the lane is full.
+func (p *processor) enqueue(ctx context.Context, l *lane, consume func(context.Context) error) error {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return errShutdown
+	}
+	if len(l.items) >= p.queueSize {
+		p.mu.Unlock()
+		p.record(ctx, l, statRefusedBatchCount.M(1))
+		return status.Errorf(codes.Unavailable, "lane %q is full", l.name)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	l.items = append(l.items, item{
+		enqueued: time.Now(),
+		consume:  consume,
+		metadata: md,
+		tags:     tag.FromContext(ctx),
+	})
+	depth := len(l.items)
+	p.cond.Signal()
+	p.mu.Unlock()
+
+	p.record(ctx, l, statQueueDepth.M(int64(depth)))
+	return nil
+}
+
+// dequeue waits for a queued batch and takes it from the lanes using a smooth
+// weighted round robin over the lanes with queued batches. It returns false
+// when the processor is stopped and all the lanes are empty.
+func (p *processor) dequeue() (*lane, item, int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		var selected *lane
+		total := 0
+		for _, l := range p.lanes {
+			if len(l.items) == 0 {
+				continue
+			}
+			l.current += l.weight
+			total += l.weight
+			if selected == nil || l.current > selected.current {
+				selected = l
+			}
+		}
+		if selected != nil {
+			selected.current -= total
+			it := selected.items[0]
+			selected.items[0] = item{}
+			selected.items = selected.items[1:]
+			return selected, it, len(selected.items), true
+		}
+		if p.stopped {
+			return nil, item{}, 0, false
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *processor) work() {
+	defer p.wg.Done()
+	for {
+		l, it, depth, ok := p.dequeue()
+		if !ok {
+			return
+		}
+		ctx := it.context()
+		p.record(ctx, l,
+			statQueueDepth.M(int64(depth)),
+			statWaitTime.M(float64(time.Since(it.enqueued))/float64(time.Millisecond)))
+		if err := it.consume(ctx); err != nil {
+			p.logger.Warn("Failed to send batch",
+				zap.String("lane", l.name),
+				zap.Error(err))
+		}
+	}
+}
+
+func (p *processor) record(ctx context.Context, l *lane, ms ...stats.Measurement) {
+	tCtx, _ := tag.New(ctx, tag.Insert(tagLane, l.name))
+	stats.Record(tCtx, ms...)
+}
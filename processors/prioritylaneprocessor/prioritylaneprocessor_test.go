@@ -0,0 +1,189 @@
+package prioritylaneprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestProcessor() *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.QueueSize = 10
+	cfg.Lanes = []LaneConfig{
+		{Name: "errors", Weight: 4, ErrorSpans: true},
+		{Name: "premium", Weight: 2, Tenants: []string{"jdoe", "acme"}},
+	}
+	return newProcessor(zap.NewNop(), cfg)
+}
+
+func newTestTraces(tenantID string, name string, code pdata.StatusCode) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(name)
+	span.Status().SetCode(code)
+	return td
+}
+
+func TestClassify(t *testing.T) {
+	p := newTestProcessor()
+	p.nextTraces = consumertest.NewNop()
+
+	tests := []struct {
+		tenantID string
+		code     pdata.StatusCode
+		lane     string
+	}{
+		{"trial", pdata.StatusCodeUnset, defaultLaneName},
+		{"jdoe", pdata.StatusCodeOk, "premium"},
+		{"trial", pdata.StatusCodeError, "errors"},
+		{"acme", pdata.StatusCodeError, "errors"},
+	}
+	for _, test := range tests {
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces(test.tenantID, "span", test.code)))
+	}
+	assert.Len(t, p.lanes[0].items, 2)
+	assert.Len(t, p.lanes[1].items, 1)
+	assert.Len(t, p.defaultLane.items, 1)
+
+	// The span tenant overrides the resource tenant.
+	td := newTestTraces("trial", "span", pdata.StatusCodeUnset)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).
+		Attributes().InsertString(defaultTenantIDAttributeKey, "acme")
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Len(t, p.lanes[1].items, 2)
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "jdoe")
+	p.nextMetrics = consumertest.NewNop()
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, p.lanes[1].items, 3)
+}
+
+func TestWeightedDequeue(t *testing.T) {
+	p := newTestProcessor()
+	sink := new(consumertest.TracesSink)
+	p.nextTraces = sink
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("trial", "default", pdata.StatusCodeUnset)))
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "premium", pdata.StatusCodeUnset)))
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("trial", "errors", pdata.StatusCodeError)))
+	}
+
+	var lanes []string
+	for i := 0; i < 7; i++ {
+		l, _, _, ok := p.dequeue()
+		require.True(t, ok)
+		lanes = append(lanes, l.name)
+	}
+	// The lanes are dequeued in proportion to their weights 4:2:1 and interleaved.
+	assert.Equal(t, []string{"errors", "premium", "errors", defaultLaneName, "errors", "premium", "errors"}, lanes)
+
+	// An empty lane does not take a share.
+	p = newTestProcessor()
+	p.nextTraces = sink
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("trial", "default", pdata.StatusCodeUnset)))
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "premium", pdata.StatusCodeUnset)))
+	}
+	lanes = nil
+	for i := 0; i < 6; i++ {
+		l, _, _, ok := p.dequeue()
+		require.True(t, ok)
+		lanes = append(lanes, l.name)
+	}
+	assert.Equal(t, []string{"premium", defaultLaneName, "premium", "premium", defaultLaneName, defaultLaneName}, lanes)
+}
+
+func TestFullLane(t *testing.T) {
+	p := newTestProcessor()
+	p.nextTraces = consumertest.NewNop()
+
+	for i := 0; i < p.queueSize; i++ {
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "span", pdata.StatusCodeUnset)))
+	}
+	err := p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "span", pdata.StatusCodeUnset))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// The other lanes are still accepting batches.
+	assert.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("trial", "span", pdata.StatusCodeUnset)))
+}
+
+func TestShutdownSendsQueuedBatches(t *testing.T) {
+	p := newTestProcessor()
+	sink := new(consumertest.TracesSink)
+	p.nextTraces = sink
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "span", pdata.StatusCodeUnset)))
+	}
+	require.NoError(t, p.Start(context.Background(), nil))
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, 5, sink.SpansCount())
+
+	assert.Equal(t, errShutdown, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "span", pdata.StatusCodeUnset)))
+}
+
+// contextConsumer records the context of the consumed traces.
+type contextConsumer struct {
+	consumertest.TracesSink
+	ctx     context.Context
+	release chan struct{}
+}
+
+func (c *contextConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	c.ctx = ctx
+	if c.release != nil {
+		<-c.release
+	}
+	return c.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestQueuedBatchKeepsContext(t *testing.T) {
+	p := newTestProcessor()
+	next := &contextConsumer{}
+	p.nextTraces = next
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "jdoe"))
+	ctx = obsreport.ReceiverContext(ctx, config.NewID("otlp"), "grpc")
+	require.NoError(t, p.ConsumeTraces(ctx, newTestTraces("jdoe", "span", pdata.StatusCodeUnset)))
+	require.NoError(t, p.Start(context.Background(), nil))
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Equal(t, 1, next.SpansCount())
+
+	md, ok := metadata.FromIncomingContext(next.ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"jdoe"}, md.Get("x-tenant-id"))
+	receiver, _ := tag.FromContext(next.ctx).Value(tag.MustNewKey("receiver"))
+	assert.Equal(t, "otlp", receiver)
+	transport, _ := tag.FromContext(next.ctx).Value(tag.MustNewKey("transport"))
+	assert.Equal(t, "grpc", transport)
+}
+
+func TestShutdownHonorsDeadline(t *testing.T) {
+	p := newTestProcessor()
+	next := &contextConsumer{release: make(chan struct{})}
+	p.nextTraces = next
+	defer close(next.release)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTestTraces("jdoe", "span", pdata.StatusCodeUnset)))
+	require.NoError(t, p.Start(context.Background(), nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, p.Shutdown(ctx))
+}
@@ -0,0 +1,104 @@
+package sensitivedataprocessor
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// classifier detects a type of sensitive data.
+type classifier struct {
+	dataType string
+	pattern  *regexp.Regexp
+	// valid checks a match to reduce false positives, e.g. by its checksum. Optional.
+	valid func(match string) bool
+}
+
+// detect returns true if the value contains a valid match.
+func (c classifier) detect(value string) bool {
+	if c.valid == nil {
+		return c.pattern.MatchString(value)
+	}
+	for _, match := range c.pattern.FindAllString(value, -1) {
+		if c.valid(match) {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinClassifiers maps the classifier names to the built-in classifiers,
+// the name is the detected data type.
+var builtinClassifiers = map[string]classifier{
+	"email": {
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	},
+	"credit_card": {
+		pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		valid:   validCardNumber,
+	},
+	"us_ssn": {
+		pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid:   validSSN,
+	},
+	"phone_number": {
+		pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]\d{3}[ .-]\d{4}\b`),
+	},
+	"iban": {
+		pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}[A-Z0-9]{11,30}\b`),
+		valid:   validIBAN,
+	},
+	"jwt": {
+		pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	},
+	"bearer_token": {
+		pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{8,}`),
+	},
+	"aws_access_key": {
+		pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	"private_key": {
+		pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`),
+	},
+}
+
+// validCardNumber checks the length and the Luhn checksum of a card number.
+func validCardNumber(match string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// validSSN rejects the area, group and serial numbers which are never assigned.
+func validSSN(match string) bool {
+	area, group, serial := match[:3], match[4:6], match[7:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// validIBAN checks the mod 97 checksum of an IBAN.
+func validIBAN(match string) bool {
+	rearranged := match[4:] + match[:4]
+	var numeric strings.Builder
+	for _, c := range rearranged {
+		if c >= 'A' && c <= 'Z' {
+			numeric.WriteString(big.NewInt(int64(c - 'A' + 10)).String())
+		} else {
+			numeric.WriteRune(c)
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
@@ -0,0 +1,62 @@
+package sensitivedataprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for sensitive data processor.
+// The processor scans the string values of span attributes for sensitive data
+// and reports the detected data types per tenant, service and attribute key.
+// It only detects, spans are never changed.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// Classifiers are the names of the enabled built-in classifiers: email,
+	// credit_card, us_ssn, phone_number, iban, jwt, bearer_token, aws_access_key
+	// and private_key. Default all.
+	Classifiers []string `mapstructure:"classifiers"`
+	// CustomClassifiers are additional classifiers matching a regular expression.
+	CustomClassifiers []CustomClassifierConfig `mapstructure:"custom_classifiers"`
+	// IgnoredAttributes are the attribute keys which are not scanned.
+	IgnoredAttributes []string `mapstructure:"ignored_attributes"`
+	// MaxValueLength is the number of leading bytes of a value which are scanned.
+	// Zero means the whole value. Default 4096.
+	MaxValueLength int `mapstructure:"max_value_length"`
+}
+
+// CustomClassifierConfig defines a classifier detecting values matching a pattern.
+type CustomClassifierConfig struct {
+	// DataType is the name of the detected data type reported in the metrics.
+	DataType string `mapstructure:"data_type"`
+	// Pattern is the regular expression matching the sensitive data.
+	Pattern string `mapstructure:"pattern"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for _, name := range cfg.Classifiers {
+		if _, ok := builtinClassifiers[name]; !ok {
+			return fmt.Errorf("unknown classifier %q", name)
+		}
+	}
+	for _, c := range cfg.CustomClassifiers {
+		if c.DataType == "" {
+			return errors.New("data_type of custom classifier is required")
+		}
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern of custom classifier %q: %w", c.DataType, err)
+		}
+	}
+	if cfg.MaxValueLength < 0 {
+		return fmt.Errorf("max_value_length must not be negative, got %d", cfg.MaxValueLength)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package sensitivedataprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	sCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, sCfg.TenantIDAttributeKey)
+	assert.Equal(t, []string{"email", "credit_card"}, sCfg.Classifiers)
+	assert.Equal(t, []CustomClassifierConfig{{DataType: "employee_id", Pattern: `EMP-\d{6}`}}, sCfg.CustomClassifiers)
+	assert.Equal(t, []string{"http.request.header.user-agent"}, sCfg.IgnoredAttributes)
+	assert.Equal(t, 1024, sCfg.MaxValueLength)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Classifiers = []string{"passport"}
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.CustomClassifiers = []CustomClassifierConfig{{Pattern: "x"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.CustomClassifiers = []CustomClassifierConfig{{DataType: "x", Pattern: "("}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.MaxValueLength = -1
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,31 @@
+package sensitivedataprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID  = tag.MustNewKey("tenant-id")
+	tagService   = tag.MustNewKey("service")
+	tagAttribute = tag.MustNewKey("attribute")
+	tagDataType  = tag.MustNewKey("data-type")
+
+	statDetectedValueCount = stats.Int64("sensitive_data_detected_value_count", "Number of attribute values containing sensitive data", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for sensitive data processor.
+func MetricViews() []*view.View {
+	viewDetectedValueCount := &view.View{
+		Name:        statDetectedValueCount.Name(),
+		Description: statDetectedValueCount.Description(),
+		Measure:     statDetectedValueCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagService, tagAttribute, tagDataType},
+	}
+
+	return []*view.View{
+		viewDetectedValueCount,
+	}
+}
@@ -0,0 +1,144 @@
+package sensitivedataprocessor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+	classifiers          []classifier
+	ignoredAttributes    map[string]bool
+	maxValueLength       int
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+func newProcessor(cfg *Config) (*processor, error) {
+	names := cfg.Classifiers
+	if len(names) == 0 {
+		for name := range builtinClassifiers {
+			names = append(names, name)
+		}
+	}
+	var classifiers []classifier
+	for _, name := range names {
+		c, ok := builtinClassifiers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown classifier %q", name)
+		}
+		c.dataType = name
+		classifiers = append(classifiers, c)
+	}
+	for _, c := range cfg.CustomClassifiers {
+		pattern, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		classifiers = append(classifiers, classifier{dataType: c.DataType, pattern: pattern})
+	}
+
+	ignoredAttributes := map[string]bool{cfg.TenantIDAttributeKey: true}
+	for _, key := range cfg.IgnoredAttributes {
+		ignoredAttributes[key] = true
+	}
+	return &processor{
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		classifiers:          classifiers,
+		ignoredAttributes:    ignoredAttributes,
+		maxValueLength:       cfg.MaxValueLength,
+	}, nil
+}
+
+// detection identifies the values of a data type found in an attribute.
+type detection struct {
+	tenantID  string
+	service   string
+	attribute string
+	dataType  string
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	for d, count := range p.detect(traces) {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, d.tenantID),
+			tag.Insert(tagService, d.service),
+			tag.Insert(tagAttribute, d.attribute),
+			tag.Insert(tagDataType, d.dataType))
+		stats.Record(tCtx, statDetectedValueCount.M(count))
+	}
+	return traces, nil
+}
+
+// detect counts the span attribute values containing sensitive data.
+func (p *processor) detect(traces pdata.Traces) map[detection]int64 {
+	detections := map[detection]int64{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		service, _ := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+
+				span.Attributes().Range(func(key string, v pdata.AttributeValue) bool {
+					if p.ignoredAttributes[key] {
+						return true
+					}
+					value := p.scannedValue(v)
+					if value == "" {
+						return true
+					}
+					for _, c := range p.classifiers {
+						if c.detect(value) {
+							detections[detection{
+								tenantID:  tenantID,
+								service:   service.StringVal(),
+								attribute: key,
+								dataType:  c.dataType,
+							}]++
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+	return detections
+}
+
+// scannedValue returns the part of the value which is scanned. Values of
+// other types than string, array and map are not scanned.
+func (p *processor) scannedValue(v pdata.AttributeValue) string {
+	var value string
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		value = v.StringVal()
+	case pdata.AttributeValueTypeArray, pdata.AttributeValueTypeMap:
+		value = tracetranslator.AttributeValueToString(v)
+	default:
+		return ""
+	}
+	if p.maxValueLength > 0 && len(value) > p.maxValueLength {
+		value = value[:p.maxValueLength]
+	}
+	return value
+}
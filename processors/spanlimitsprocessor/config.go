@@ -0,0 +1,59 @@
+package spanlimitsprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for span limits processor.
+// The processor truncates spans exceeding the configured limits so that
+// exported messages stay below the size accepted by the exporters.
+// Truncated data is marked with a "*.truncated" attribute set to true.
+// A limit of 0 disables the corresponding check.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	// The tenant ID attribute is never removed from a span nor truncated.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// MaxAttributeValueLength is the maximum length in bytes of a string attribute
+	// value of spans and span events.
+	MaxAttributeValueLength int `mapstructure:"max_attribute_value_length"`
+	// MaxAttributesPerSpan is the maximum number of attributes of a span,
+	// including the tenant ID and the markers. It must be at least 4 to hold
+	// the tenant ID and the attributes, events and links markers.
+	MaxAttributesPerSpan int `mapstructure:"max_attributes_per_span"`
+	// MaxEventsPerSpan is the maximum number of events of a span.
+	MaxEventsPerSpan int `mapstructure:"max_events_per_span"`
+	// MaxLinksPerSpan is the maximum number of links of a span.
+	MaxLinksPerSpan int `mapstructure:"max_links_per_span"`
+	// MaxSpansPerResource is the maximum number of spans of a single resource in a batch.
+	MaxSpansPerResource int `mapstructure:"max_spans_per_resource"`
+}
+
+// minAttributesPerSpan is the number of attribute slots reserved for the
+// tenant ID and the attributes, events and links markers.
+const minAttributesPerSpan = 4
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	limits := map[string]int{
+		"max_attribute_value_length": cfg.MaxAttributeValueLength,
+		"max_attributes_per_span":    cfg.MaxAttributesPerSpan,
+		"max_events_per_span":        cfg.MaxEventsPerSpan,
+		"max_links_per_span":         cfg.MaxLinksPerSpan,
+		"max_spans_per_resource":     cfg.MaxSpansPerResource,
+	}
+	for name, limit := range limits {
+		if limit < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", name, limit)
+		}
+	}
+	if cfg.MaxAttributesPerSpan > 0 && cfg.MaxAttributesPerSpan < minAttributesPerSpan {
+		return fmt.Errorf("max_attributes_per_span must be 0 or at least %d, got %d", minAttributesPerSpan, cfg.MaxAttributesPerSpan)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package spanlimitsprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	slCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", slCfg.TenantIDAttributeKey)
+	assert.Equal(t, 1024, slCfg.MaxAttributeValueLength)
+	assert.Equal(t, 64, slCfg.MaxAttributesPerSpan)
+	assert.Equal(t, 16, slCfg.MaxEventsPerSpan)
+	assert.Equal(t, 8, slCfg.MaxLinksPerSpan)
+	assert.Equal(t, 1000, slCfg.MaxSpansPerResource)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxEventsPerSpan = -1
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.MaxAttributesPerSpan = minAttributesPerSpan - 1
+	assert.Error(t, cfg.Validate())
+	cfg.MaxAttributesPerSpan = minAttributesPerSpan
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,62 @@
+package spanlimitsprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                        = "hypertrace_spanlimits"
+	defaultTenantIDAttributeKey    = "tenant-id"
+	defaultMaxAttributeValueLength = 128 * 1024
+	defaultMaxAttributesPerSpan    = 1024
+	defaultMaxEventsPerSpan        = 128
+	defaultMaxLinksPerSpan         = 128
+)
+
+// NewFactory creates a factory for the span limits processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey:    defaultTenantIDAttributeKey,
+		MaxAttributeValueLength: defaultMaxAttributeValueLength,
+		MaxAttributesPerSpan:    defaultMaxAttributesPerSpan,
+		MaxEventsPerSpan:        defaultMaxEventsPerSpan,
+		MaxLinksPerSpan:         defaultMaxLinksPerSpan,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	pCfg := cfg.(*Config)
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		&processor{
+			tenantIDAttributeKey:    pCfg.TenantIDAttributeKey,
+			maxAttributeValueLength: pCfg.MaxAttributeValueLength,
+			maxAttributesPerSpan:    pCfg.MaxAttributesPerSpan,
+			maxEventsPerSpan:        pCfg.MaxEventsPerSpan,
+			maxLinksPerSpan:         pCfg.MaxLinksPerSpan,
+			maxSpansPerResource:     pCfg.MaxSpansPerResource,
+		})
+}
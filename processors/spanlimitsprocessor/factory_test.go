@@ -0,0 +1,18 @@
+package spanlimitsprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultMaxAttributeValueLength, cfg.MaxAttributeValueLength)
+	assert.Equal(t, defaultMaxAttributesPerSpan, cfg.MaxAttributesPerSpan)
+	assert.Equal(t, defaultMaxEventsPerSpan, cfg.MaxEventsPerSpan)
+	assert.Equal(t, defaultMaxLinksPerSpan, cfg.MaxLinksPerSpan)
+	assert.Equal(t, 0, cfg.MaxSpansPerResource)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,39 @@
+package spanlimitsprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagLimit    = tag.MustNewKey("limit")
+
+	statTruncationPerTenant = stats.Int64("span_limits_truncation_count", "Number of truncations applied to data received from a tenant", stats.UnitDimensionless)
+)
+
+const (
+	limitAttributeValueLength = "attribute_value_length"
+	limitAttributesPerSpan    = "attributes_per_span"
+	limitEventsPerSpan        = "events_per_span"
+	limitLinksPerSpan         = "links_per_span"
+	limitSpansPerResource     = "spans_per_resource"
+)
+
+// MetricViews returns the metrics views for span limits processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID, tagLimit}
+
+	viewTruncationCount := &view.View{
+		Name:        statTruncationPerTenant.Name(),
+		Description: statTruncationPerTenant.Description(),
+		Measure:     statTruncationPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewTruncationCount,
+	}
+}
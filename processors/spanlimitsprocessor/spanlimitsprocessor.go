@@ -0,0 +1,229 @@
+package spanlimitsprocessor
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	truncatedSuffix = ".truncated"
+	// Markers set on a span or resource when some of its items were removed.
+	attributesTruncatedKey = "attributes" + truncatedSuffix
+	eventsTruncatedKey     = "events" + truncatedSuffix
+	linksTruncatedKey      = "links" + truncatedSuffix
+	spansTruncatedKey      = "spans" + truncatedSuffix
+)
+
+type processor struct {
+	tenantIDAttributeKey    string
+	maxAttributeValueLength int
+	maxAttributesPerSpan    int
+	maxEventsPerSpan        int
+	maxLinksPerSpan         int
+	maxSpansPerResource     int
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+// truncations counts truncations per tenant and limit.
+type truncations map[string]map[string]int64
+
+func (t truncations) add(tenantID, limit string, count int) {
+	if count == 0 {
+		return
+	}
+	if _, ok := t[tenantID]; !ok {
+		t[tenantID] = map[string]int64{}
+	}
+	t[tenantID][limit] += int64(count)
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	counts := truncations{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				p.limitSpan(span, tenantID, counts)
+			}
+		}
+
+		if dropped := p.limitSpans(rs); dropped > 0 {
+			rs.Resource().Attributes().UpsertBool(spansTruncatedKey, true)
+			counts.add(resourceTenantID.StringVal(), limitSpansPerResource, dropped)
+		}
+	}
+
+	for tenantID, limits := range counts {
+		for limit, count := range limits {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagLimit, limit))
+			stats.Record(tCtx, statTruncationPerTenant.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+func (p *processor) limitSpan(span pdata.Span, tenantID string, counts truncations) {
+	events := span.Events()
+	links := span.Links()
+	dropEvents := p.maxEventsPerSpan > 0 && events.Len() > p.maxEventsPerSpan
+	dropLinks := p.maxLinksPerSpan > 0 && links.Len() > p.maxLinksPerSpan
+
+	// The markers of the events and links take attribute slots too.
+	markers := 0
+	if dropEvents {
+		markers++
+	}
+	if dropLinks {
+		markers++
+	}
+	dropped, truncated := p.limitAttributes(span.Attributes(), markers)
+	counts.add(tenantID, limitAttributeValueLength, truncated)
+	if dropped > 0 {
+		span.SetDroppedAttributesCount(span.DroppedAttributesCount() + uint32(dropped))
+		span.Attributes().UpsertBool(attributesTruncatedKey, true)
+		counts.add(tenantID, limitAttributesPerSpan, dropped)
+	}
+
+	if dropEvents {
+		dropped := events.Len() - p.maxEventsPerSpan
+		events.Resize(p.maxEventsPerSpan)
+		span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(dropped))
+		span.Attributes().UpsertBool(eventsTruncatedKey, true)
+		counts.add(tenantID, limitEventsPerSpan, dropped)
+	}
+	for i := 0; i < events.Len(); i++ {
+		counts.add(tenantID, limitAttributeValueLength, p.truncateValues(events.At(i).Attributes()))
+	}
+
+	if dropLinks {
+		dropped := links.Len() - p.maxLinksPerSpan
+		links.Resize(p.maxLinksPerSpan)
+		span.SetDroppedLinksCount(span.DroppedLinksCount() + uint32(dropped))
+		span.Attributes().UpsertBool(linksTruncatedKey, true)
+		counts.add(tenantID, limitLinksPerSpan, dropped)
+	}
+}
+
+// limitAttributes removes the span attributes exceeding the limit and then
+// truncates the values of the kept ones. The tenant ID attribute is never
+// removed nor truncated. Slots are reserved for the tenant ID, the given
+// number of markers and the markers added by this function, so that the
+// span does not exceed the limit once they are set. It returns the number
+// of removed attributes and the number of truncated values.
+func (p *processor) limitAttributes(attrs pdata.AttributeMap, markers int) (int, int) {
+	// cost is the number of slots taken by an attribute and its truncation marker.
+	cost := func(k string, v pdata.AttributeValue) int {
+		if k != p.tenantIDAttributeKey && p.isTooLong(v) {
+			return 2
+		}
+		return 1
+	}
+
+	var dropKeys []string
+	if p.maxAttributesPerSpan > 0 {
+		total := markers
+		attrs.Range(func(k string, v pdata.AttributeValue) bool {
+			total += cost(k, v)
+			return true
+		})
+		if total > p.maxAttributesPerSpan {
+			// The attributes marker is needed.
+			budget := p.maxAttributesPerSpan - markers - 1
+			if _, ok := attrs.Get(p.tenantIDAttributeKey); ok {
+				budget--
+			}
+			attrs.Range(func(k string, v pdata.AttributeValue) bool {
+				if k == p.tenantIDAttributeKey {
+					return true
+				}
+				if c := cost(k, v); c <= budget {
+					budget -= c
+					return true
+				}
+				dropKeys = append(dropKeys, k)
+				return true
+			})
+			for _, k := range dropKeys {
+				attrs.Delete(k)
+			}
+		}
+	}
+
+	return len(dropKeys), p.truncateValues(attrs)
+}
+
+func (p *processor) isTooLong(v pdata.AttributeValue) bool {
+	return p.maxAttributeValueLength > 0 &&
+		v.Type() == pdata.AttributeValueTypeString &&
+		len(v.StringVal()) > p.maxAttributeValueLength
+}
+
+// truncateValues shortens string values longer than the limit and marks
+// each of them with a "<key>.truncated" attribute. The tenant ID attribute
+// is not truncated. It returns the number of truncated values.
+func (p *processor) truncateValues(attrs pdata.AttributeMap) int {
+	var truncatedKeys []string
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if k != p.tenantIDAttributeKey && p.isTooLong(v) {
+			v.SetStringVal(truncateString(v.StringVal(), p.maxAttributeValueLength))
+			truncatedKeys = append(truncatedKeys, k)
+		}
+		return true
+	})
+	for _, k := range truncatedKeys {
+		attrs.UpsertBool(k+truncatedSuffix, true)
+	}
+	return len(truncatedKeys)
+}
+
+// truncateString cuts s to at most maxLen bytes without splitting a UTF-8 character.
+func truncateString(s string, maxLen int) string {
+	end := maxLen
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// limitSpans removes spans of the resource exceeding the limit and returns the number of removed spans.
+func (p *processor) limitSpans(rs pdata.ResourceSpans) int {
+	if p.maxSpansPerResource == 0 {
+		return 0
+	}
+
+	dropped := 0
+	kept := 0
+	rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+		ils.Spans().RemoveIf(func(pdata.Span) bool {
+			if kept < p.maxSpansPerResource {
+				kept++
+				return false
+			}
+			dropped++
+			return true
+		})
+		return ils.Spans().Len() == 0
+	})
+	return dropped
+}
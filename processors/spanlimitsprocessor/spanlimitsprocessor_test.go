@@ -0,0 +1,172 @@
+package spanlimitsprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const testTenantID = "jdoe"
+
+func newTestProcessor() *processor {
+	return &processor{
+		tenantIDAttributeKey:    defaultTenantIDAttributeKey,
+		maxAttributeValueLength: 8,
+		maxAttributesPerSpan:    5,
+		maxEventsPerSpan:        2,
+		maxLinksPerSpan:         1,
+		maxSpansPerResource:     2,
+	}
+}
+
+func generateTraces(numSpans int) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, testTenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i := 0; i < numSpans; i++ {
+		span := spans.AppendEmpty()
+		span.SetName(fmt.Sprintf("span-%d", i))
+	}
+	return td
+}
+
+func TestAttributeValueTruncation(t *testing.T) {
+	td := generateTraces(1)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Attributes().InsertString("http.request.body", strings.Repeat("a", 100))
+	span.Attributes().InsertString("short", "abc")
+	event := span.Events().AppendEmpty()
+	event.Attributes().InsertString("exception.stacktrace", strings.Repeat("b", 100))
+
+	p := newTestProcessor()
+	p.maxAttributesPerSpan = 0
+	_, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	body, _ := span.Attributes().Get("http.request.body")
+	assert.Equal(t, "aaaaaaaa", body.StringVal())
+	marker, ok := span.Attributes().Get("http.request.body.truncated")
+	require.True(t, ok)
+	assert.True(t, marker.BoolVal())
+	short, _ := span.Attributes().Get("short")
+	assert.Equal(t, "abc", short.StringVal())
+	_, ok = span.Attributes().Get("short.truncated")
+	assert.False(t, ok)
+
+	stacktrace, _ := event.Attributes().Get("exception.stacktrace")
+	assert.Equal(t, "bbbbbbbb", stacktrace.StringVal())
+	_, ok = event.Attributes().Get("exception.stacktrace.truncated")
+	assert.True(t, ok)
+}
+
+func TestTruncateStringKeepsValidUTF8(t *testing.T) {
+	assert.Equal(t, "ab", truncateString("ab€", 4))
+	assert.Equal(t, "ab€", truncateString("ab€c", 5))
+}
+
+func TestAttributesPerSpanKeepsTenantID(t *testing.T) {
+	td := generateTraces(1)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	for i := 0; i < 5; i++ {
+		span.Attributes().InsertInt(fmt.Sprintf("attr-%d", i), int64(i))
+	}
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, testTenantID)
+
+	p := newTestProcessor()
+	_, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	attrs := span.Attributes()
+	assert.LessOrEqual(t, attrs.Len(), p.maxAttributesPerSpan)
+	_, ok := attrs.Get(defaultTenantIDAttributeKey)
+	assert.True(t, ok)
+	_, ok = attrs.Get("attr-2")
+	assert.True(t, ok)
+	_, ok = attrs.Get("attr-3")
+	assert.False(t, ok)
+	_, ok = attrs.Get(attributesTruncatedKey)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), span.DroppedAttributesCount())
+}
+
+func TestAttributesPerSpanReservesMarkers(t *testing.T) {
+	td := generateTraces(1)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Attributes().InsertString("long-0", strings.Repeat("a", 100))
+	for i := 0; i < 3; i++ {
+		span.Attributes().InsertInt(fmt.Sprintf("attr-%d", i), int64(i))
+	}
+	span.Attributes().InsertString("long-1", strings.Repeat("a", 100))
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, testTenantID)
+	span.Events().Resize(5)
+	span.Links().Resize(3)
+
+	p := newTestProcessor()
+	p.maxAttributesPerSpan = 7
+	_, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	attrs := span.Attributes()
+	assert.LessOrEqual(t, attrs.Len(), p.maxAttributesPerSpan)
+	for _, key := range []string{defaultTenantIDAttributeKey, attributesTruncatedKey, eventsTruncatedKey, linksTruncatedKey} {
+		_, ok := attrs.Get(key)
+		assert.True(t, ok, key)
+	}
+	// Every kept truncated value keeps its marker.
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if v.Type() == pdata.AttributeValueTypeString && k != defaultTenantIDAttributeKey {
+			_, ok := attrs.Get(k + truncatedSuffix)
+			assert.True(t, ok, k)
+		}
+		return true
+	})
+	assert.Equal(t, uint32(6-3), span.DroppedAttributesCount())
+}
+
+func TestEventsAndLinksPerSpan(t *testing.T) {
+	td := generateTraces(1)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Events().Resize(5)
+	span.Links().Resize(3)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, uint32(3), span.DroppedEventsCount())
+	assert.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, uint32(2), span.DroppedLinksCount())
+	_, ok := span.Attributes().Get(eventsTruncatedKey)
+	assert.True(t, ok)
+	_, ok = span.Attributes().Get(linksTruncatedKey)
+	assert.True(t, ok)
+}
+
+func TestSpansPerResource(t *testing.T) {
+	td := generateTraces(5)
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, td.SpanCount())
+	marker, ok := td.ResourceSpans().At(0).Resource().Attributes().Get(spansTruncatedKey)
+	require.True(t, ok)
+	assert.True(t, marker.BoolVal())
+}
+
+func TestWithinLimitsIsUnchanged(t *testing.T) {
+	td := generateTraces(1)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Attributes().InsertString("short", "abc")
+	expected := td.Clone()
+
+	_, err := newTestProcessor().ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, td)
+}
@@ -0,0 +1,141 @@
+package tailsamplingprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// PolicyType identifies a sampling policy.
+type PolicyType string
+
+const (
+	// AlwaysSample samples every trace.
+	AlwaysSample PolicyType = "always_sample"
+	// StatusCode samples traces containing a span with one of the configured status codes.
+	StatusCode PolicyType = "status_code"
+	// Latency samples traces whose duration is equal or longer than the configured threshold.
+	Latency PolicyType = "latency"
+	// StringAttribute samples traces containing a span with a matching attribute value.
+	StringAttribute PolicyType = "string_attribute"
+	// Probabilistic samples a percentage of traces based on the trace ID hash.
+	Probabilistic PolicyType = "probabilistic"
+)
+
+// Config defines config for tail sampling processor.
+// The processor buffers spans by trace ID for DecisionWait and then evaluates
+// the sampling policies of the tenant the trace belongs to. The tenant is read
+// from the tenant ID attribute, therefore this processor has to run after
+// the tenant ID processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// DecisionWait is the time since the first span of a trace was received
+	// after which the sampling decision is made. Default 30s.
+	DecisionWait time.Duration `mapstructure:"decision_wait"`
+	// NumTraces is the maximum number of traces kept in memory. When the limit is
+	// reached the oldest trace is evaluated early. Default 50000.
+	NumTraces int `mapstructure:"num_traces"`
+	// DefaultPolicies are evaluated for tenants without a dedicated entry in TenantPolicies.
+	// When empty, all traces of such tenants are sampled.
+	DefaultPolicies []PolicyConfig `mapstructure:"default_policies"`
+	// TenantPolicies maps tenant ID to the policies evaluated for its traces.
+	TenantPolicies map[string][]PolicyConfig `mapstructure:"tenant_policies"`
+}
+
+// PolicyConfig holds the configuration of a single sampling policy.
+// A trace is sampled as soon as one of the policies samples it.
+type PolicyConfig struct {
+	// Name of the policy, used in the decision metrics.
+	Name string `mapstructure:"name"`
+	// Type of the policy.
+	Type PolicyType `mapstructure:"type"`
+	// StatusCode configures the status_code policy.
+	StatusCode StatusCodeConfig `mapstructure:"status_code"`
+	// Latency configures the latency policy.
+	Latency LatencyConfig `mapstructure:"latency"`
+	// StringAttribute configures the string_attribute policy.
+	StringAttribute StringAttributeConfig `mapstructure:"string_attribute"`
+	// Probabilistic configures the probabilistic policy.
+	Probabilistic ProbabilisticConfig `mapstructure:"probabilistic"`
+}
+
+// StatusCodeConfig holds the status codes (OK, ERROR, UNSET) that sample a trace.
+type StatusCodeConfig struct {
+	StatusCodes []string `mapstructure:"status_codes"`
+}
+
+// LatencyConfig holds the trace duration threshold.
+type LatencyConfig struct {
+	Threshold time.Duration `mapstructure:"threshold"`
+}
+
+// StringAttributeConfig holds the attribute key and the values that sample a trace.
+type StringAttributeConfig struct {
+	Key    string   `mapstructure:"key"`
+	Values []string `mapstructure:"values"`
+}
+
+// ProbabilisticConfig holds the percentage of traces to sample.
+type ProbabilisticConfig struct {
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.DecisionWait <= 0 {
+		return fmt.Errorf("decision_wait must be positive, got %s", cfg.DecisionWait)
+	}
+	if cfg.NumTraces <= 0 {
+		return fmt.Errorf("num_traces must be positive, got %d", cfg.NumTraces)
+	}
+	if err := validatePolicies(cfg.DefaultPolicies); err != nil {
+		return fmt.Errorf("invalid default policies: %w", err)
+	}
+	for tenantID, policies := range cfg.TenantPolicies {
+		if err := validatePolicies(policies); err != nil {
+			return fmt.Errorf("invalid policies for tenant %q: %w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+func validatePolicies(policies []PolicyConfig) error {
+	for _, p := range policies {
+		if p.Name == "" {
+			return fmt.Errorf("policy of type %q has no name", p.Type)
+		}
+		switch p.Type {
+		case AlwaysSample:
+		case StatusCode:
+			if len(p.StatusCode.StatusCodes) == 0 {
+				return fmt.Errorf("policy %q: status_codes must not be empty", p.Name)
+			}
+			for _, c := range p.StatusCode.StatusCodes {
+				if _, ok := statusCodes[c]; !ok {
+					return fmt.Errorf("policy %q: unknown status code %q", p.Name, c)
+				}
+			}
+		case Latency:
+			if p.Latency.Threshold <= 0 {
+				return fmt.Errorf("policy %q: latency threshold must be positive", p.Name)
+			}
+		case StringAttribute:
+			if p.StringAttribute.Key == "" {
+				return fmt.Errorf("policy %q: string attribute key must not be empty", p.Name)
+			}
+		case Probabilistic:
+			if p.Probabilistic.SamplingPercentage < 0 || p.Probabilistic.SamplingPercentage > 100 {
+				return fmt.Errorf("policy %q: sampling_percentage must be between 0 and 100", p.Name)
+			}
+		default:
+			return fmt.Errorf("policy %q: unknown type %q", p.Name, p.Type)
+		}
+	}
+	return nil
+}
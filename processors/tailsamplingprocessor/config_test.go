@@ -0,0 +1,52 @@
+package tailsamplingprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	tsCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "attribute-tenant", tsCfg.TenantIDAttributeKey)
+	assert.Equal(t, 10*time.Second, tsCfg.DecisionWait)
+	assert.Equal(t, 100, tsCfg.NumTraces)
+	assert.Empty(t, tsCfg.DefaultPolicies)
+	assert.Equal(t, []PolicyConfig{
+		{Name: "errors", Type: StatusCode, StatusCode: StatusCodeConfig{StatusCodes: []string{"ERROR"}}},
+		{Name: "slow", Type: Latency, Latency: LatencyConfig{Threshold: 5 * time.Second}},
+		{Name: "debug", Type: StringAttribute, StringAttribute: StringAttributeConfig{Key: "debug", Values: []string{"true"}}},
+		{Name: "rest", Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 5}},
+	}, tsCfg.TenantPolicies["big-tenant"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultPolicies = []PolicyConfig{{Name: "unknown", Type: "unknown"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.TenantPolicies = map[string][]PolicyConfig{
+		"jdoe": {{Name: "errors", Type: StatusCode, StatusCode: StatusCodeConfig{StatusCodes: []string{"FAILED"}}}},
+	}
+	assert.Error(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.DefaultPolicies = []PolicyConfig{{Name: "rest", Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 120}}}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,51 @@
+package tailsamplingprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagPolicy   = tag.MustNewKey("policy")
+	tagDecision = tag.MustNewKey("decision")
+
+	statTraceDecision = stats.Int64("tail_sampling_trace_decision_count", "Number of traces a sampling decision was made for", stats.UnitDimensionless)
+	statSpanDecision  = stats.Int64("tail_sampling_span_decision_count", "Number of spans a sampling decision was made for", stats.UnitDimensionless)
+)
+
+const (
+	decisionSampled    = "sampled"
+	decisionNotSampled = "not_sampled"
+	// policyNone is recorded when no policy sampled the trace.
+	policyNone = "none"
+	// policyDefault is recorded when the tenant has no policies and everything is sampled.
+	policyDefault = "default"
+)
+
+// MetricViews returns the metrics views for tail sampling processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID, tagPolicy, tagDecision}
+
+	viewTraceDecision := &view.View{
+		Name:        statTraceDecision.Name(),
+		Description: statTraceDecision.Description(),
+		Measure:     statTraceDecision,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	viewSpanDecision := &view.View{
+		Name:        statSpanDecision.Name(),
+		Description: statSpanDecision.Description(),
+		Measure:     statSpanDecision,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewTraceDecision,
+		viewSpanDecision,
+	}
+}
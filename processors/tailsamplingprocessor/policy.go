@@ -0,0 +1,122 @@
+package tailsamplingprocessor
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+var statusCodes = map[string]pdata.StatusCode{
+	"UNSET": pdata.StatusCodeUnset,
+	"OK":    pdata.StatusCodeOk,
+	"ERROR": pdata.StatusCodeError,
+}
+
+// policyEvaluator decides whether a buffered trace should be sampled.
+type policyEvaluator interface {
+	shouldSample(traceID pdata.TraceID, trace *traceData) bool
+}
+
+type policy struct {
+	name      string
+	evaluator policyEvaluator
+}
+
+func newPolicies(cfgs []PolicyConfig) []policy {
+	policies := make([]policy, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		policies = append(policies, policy{name: cfg.Name, evaluator: newPolicyEvaluator(cfg)})
+	}
+	return policies
+}
+
+func newPolicyEvaluator(cfg PolicyConfig) policyEvaluator {
+	switch cfg.Type {
+	case StatusCode:
+		codes := map[pdata.StatusCode]bool{}
+		for _, c := range cfg.StatusCode.StatusCodes {
+			codes[statusCodes[c]] = true
+		}
+		return &statusCodePolicy{codes: codes}
+	case Latency:
+		return &latencyPolicy{threshold: uint64(cfg.Latency.Threshold.Nanoseconds())}
+	case StringAttribute:
+		values := map[string]bool{}
+		for _, v := range cfg.StringAttribute.Values {
+			values[v] = true
+		}
+		return &stringAttributePolicy{key: cfg.StringAttribute.Key, values: values}
+	case Probabilistic:
+		return &probabilisticPolicy{threshold: uint64(cfg.Probabilistic.SamplingPercentage / 100 * (math.MaxUint32 + 1))}
+	default:
+		return alwaysSamplePolicy{}
+	}
+}
+
+type alwaysSamplePolicy struct{}
+
+func (alwaysSamplePolicy) shouldSample(pdata.TraceID, *traceData) bool {
+	return true
+}
+
+type statusCodePolicy struct {
+	codes map[pdata.StatusCode]bool
+}
+
+func (p *statusCodePolicy) shouldSample(_ pdata.TraceID, trace *traceData) bool {
+	return trace.anySpan(func(span pdata.Span) bool {
+		return p.codes[span.Status().Code()]
+	})
+}
+
+type latencyPolicy struct {
+	threshold uint64
+}
+
+func (p *latencyPolicy) shouldSample(_ pdata.TraceID, trace *traceData) bool {
+	var start, end pdata.Timestamp
+	trace.anySpan(func(span pdata.Span) bool {
+		if start == 0 || span.StartTimestamp() < start {
+			start = span.StartTimestamp()
+		}
+		if span.EndTimestamp() > end {
+			end = span.EndTimestamp()
+		}
+		return false
+	})
+	return end > start && uint64(end-start) >= p.threshold
+}
+
+type stringAttributePolicy struct {
+	key    string
+	values map[string]bool
+}
+
+func (p *stringAttributePolicy) shouldSample(_ pdata.TraceID, trace *traceData) bool {
+	return trace.anySpan(func(span pdata.Span) bool {
+		v, ok := span.Attributes().Get(p.key)
+		if !ok {
+			return false
+		}
+		// Without configured values the presence of the attribute is enough.
+		return len(p.values) == 0 || p.values[tracetranslator.AttributeValueToString(v)]
+	})
+}
+
+type probabilisticPolicy struct {
+	threshold uint64
+}
+
+func (p *probabilisticPolicy) shouldSample(traceID pdata.TraceID, _ *traceData) bool {
+	return uint64(traceIDHash(traceID)) < p.threshold
+}
+
+// traceIDHash returns a hash of the trace ID which is stable across collector instances.
+func traceIDHash(traceID pdata.TraceID) uint32 {
+	b := traceID.Bytes()
+	h := fnv.New32a()
+	h.Write(b[:])
+	return h.Sum32()
+}
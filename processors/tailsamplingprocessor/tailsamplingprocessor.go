@@ -0,0 +1,274 @@
+package tailsamplingprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// tickInterval is the period in which buffered traces are checked for a decision.
+const tickInterval = time.Second
+
+type traceData struct {
+	tenantID  string
+	arrival   time.Time
+	spanCount int
+	batches   pdata.ResourceSpansSlice
+}
+
+// anySpan returns true as soon as f returns true for a span of the trace.
+func (t *traceData) anySpan(f func(pdata.Span) bool) bool {
+	for i := 0; i < t.batches.Len(); i++ {
+		ilss := t.batches.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if f(spans.At(k)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+type processor struct {
+	nextConsumer         consumer.Traces
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	decisionWait         time.Duration
+	numTraces            int
+	defaultPolicies      []policy
+	tenantPolicies       map[string][]policy
+
+	mu     sync.Mutex
+	traces map[[16]byte]*traceData
+	// order holds trace IDs in the order of their arrival.
+	order [][16]byte
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) *processor {
+	tenantPolicies := make(map[string][]policy, len(cfg.TenantPolicies))
+	for tenantID, policies := range cfg.TenantPolicies {
+		tenantPolicies[tenantID] = newPolicies(policies)
+	}
+	return &processor{
+		nextConsumer:         nextConsumer,
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		decisionWait:         cfg.DecisionWait,
+		numTraces:            cfg.NumTraces,
+		defaultPolicies:      newPolicies(cfg.DefaultPolicies),
+		tenantPolicies:       tenantPolicies,
+		traces:               map[[16]byte]*traceData{},
+		done:                 make(chan struct{}),
+	}
+}
+
+// Start implements component.Component
+func (p *processor) Start(context.Context, component.Host) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case now := <-ticker.C:
+				p.flush(now, false)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements component.Component. Buffered traces are evaluated immediately.
+func (p *processor) Shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	p.flush(time.Now(), true)
+	return nil
+}
+
+// Capabilities implements consumer.Traces
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces implements consumer.Traces. Spans are buffered until a decision is made for their trace.
+func (p *processor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	now := time.Now()
+	batches := p.groupByTrace(td)
+
+	var evicted []decidedTrace
+	p.mu.Lock()
+	for traceID, b := range batches {
+		trace, ok := p.traces[traceID]
+		if !ok {
+			trace = &traceData{tenantID: b.tenantID, arrival: now, batches: pdata.NewResourceSpansSlice()}
+			p.traces[traceID] = trace
+			p.order = append(p.order, traceID)
+		}
+		b.batches.MoveAndAppendTo(trace.batches)
+		trace.spanCount += b.spanCount
+	}
+	for len(p.traces) > p.numTraces {
+		evicted = append(evicted, p.popOldest())
+	}
+	p.mu.Unlock()
+
+	p.export(evicted)
+	return nil
+}
+
+type decidedTrace struct {
+	traceID [16]byte
+	trace   *traceData
+}
+
+// popOldest removes the oldest trace from the buffer. Must be called with mu held.
+func (p *processor) popOldest() decidedTrace {
+	traceID := p.order[0]
+	p.order = p.order[1:]
+	trace := p.traces[traceID]
+	delete(p.traces, traceID)
+	return decidedTrace{traceID: traceID, trace: trace}
+}
+
+// flush evaluates traces older than the decision wait, or all traces if all is set.
+func (p *processor) flush(now time.Time, all bool) {
+	var ready []decidedTrace
+	p.mu.Lock()
+	for len(p.order) > 0 {
+		if !all && p.traces[p.order[0]].arrival.Add(p.decisionWait).After(now) {
+			break
+		}
+		ready = append(ready, p.popOldest())
+	}
+	p.mu.Unlock()
+
+	p.export(ready)
+}
+
+// export makes the sampling decision for the given traces and forwards the sampled ones.
+func (p *processor) export(traces []decidedTrace) {
+	if len(traces) == 0 {
+		return
+	}
+
+	td := pdata.NewTraces()
+	for _, t := range traces {
+		if !p.decide(pdata.NewTraceID(t.traceID), t.trace) {
+			continue
+		}
+		t.trace.batches.MoveAndAppendTo(td.ResourceSpans())
+	}
+
+	if td.ResourceSpans().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeTraces(context.Background(), td); err != nil {
+		p.logger.Error("Failed to export sampled traces", zap.Error(err))
+	}
+}
+
+func (p *processor) decide(traceID pdata.TraceID, trace *traceData) bool {
+	policies, ok := p.tenantPolicies[trace.tenantID]
+	if !ok {
+		policies = p.defaultPolicies
+	}
+
+	sampled := len(policies) == 0
+	policyName := policyDefault
+	if !sampled {
+		policyName = policyNone
+		for _, pol := range policies {
+			if pol.evaluator.shouldSample(traceID, trace) {
+				sampled = true
+				policyName = pol.name
+				break
+			}
+		}
+	}
+
+	decision := decisionNotSampled
+	if sampled {
+		decision = decisionSampled
+	}
+	ctx, _ := tag.New(context.Background(),
+		tag.Insert(tagTenantID, trace.tenantID),
+		tag.Insert(tagPolicy, policyName),
+		tag.Insert(tagDecision, decision))
+	stats.Record(ctx, statTraceDecision.M(1), statSpanDecision.M(int64(trace.spanCount)))
+
+	return sampled
+}
+
+type traceBatches struct {
+	tenantID  string
+	spanCount int
+	batches   pdata.ResourceSpansSlice
+}
+
+// groupByTrace copies the spans of td into resource spans holding a single trace each.
+func (p *processor) groupByTrace(td pdata.Traces) map[[16]byte]*traceBatches {
+	result := map[[16]byte]*traceBatches{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		traceRSs := map[[16]byte]pdata.ResourceSpans{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+
+			traceILSs := map[[16]byte]pdata.InstrumentationLibrarySpans{}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				traceID := span.TraceID().Bytes()
+
+				b, ok := result[traceID]
+				if !ok {
+					b = &traceBatches{tenantID: resourceTenantID.StringVal(), batches: pdata.NewResourceSpansSlice()}
+					result[traceID] = b
+				}
+
+				traceILS, ok := traceILSs[traceID]
+				if !ok {
+					traceRS, ok := traceRSs[traceID]
+					if !ok {
+						traceRS = b.batches.AppendEmpty()
+						rs.Resource().CopyTo(traceRS.Resource())
+						traceRSs[traceID] = traceRS
+					}
+					traceILS = traceRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(traceILS.InstrumentationLibrary())
+					traceILSs[traceID] = traceILS
+				}
+				span.CopyTo(traceILS.Spans().AppendEmpty())
+
+				if tenantID, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok && b.tenantID == "" {
+					b.tenantID = tenantID.StringVal()
+				}
+				b.spanCount++
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	smallTenantID = "small"
+	bigTenantID   = "big"
+)
+
+var testStartTime = time.Date(2020, 2, 11, 20, 26, 12, 321, time.UTC)
+
+type testSpan struct {
+	traceID  byte
+	spanID   byte
+	status   pdata.StatusCode
+	duration time.Duration
+}
+
+func generateTraces(tenantID string, testSpans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, ts := range testSpans {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{ts.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{ts.spanID}))
+		span.SetStartTimestamp(pdata.TimestampFromTime(testStartTime))
+		span.SetEndTimestamp(pdata.TimestampFromTime(testStartTime.Add(ts.duration)))
+		span.Status().SetCode(ts.status)
+		span.Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	}
+	return td
+}
+
+func newTestProcessor(sink *consumertest.TracesSink) *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantPolicies = map[string][]PolicyConfig{
+		bigTenantID: {
+			{Name: "errors", Type: StatusCode, StatusCode: StatusCodeConfig{StatusCodes: []string{"ERROR"}}},
+			{Name: "slow", Type: Latency, Latency: LatencyConfig{Threshold: time.Second}},
+			{Name: "rest", Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 0}},
+		},
+	}
+	return newProcessor(zap.NewNop(), cfg, sink)
+}
+
+func sampledTraceIDs(sink *consumertest.TracesSink) []byte {
+	var ids []byte
+	seen := map[byte]bool{}
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					id := spans.At(k).TraceID().Bytes()[0]
+					if !seen[id] {
+						seen[id] = true
+						ids = append(ids, id)
+					}
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func TestTenantWithoutPoliciesKeepsEverything(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink)
+
+	err := p.ConsumeTraces(context.Background(), generateTraces(smallTenantID,
+		testSpan{traceID: 1, spanID: 1, duration: time.Millisecond},
+		testSpan{traceID: 2, spanID: 2, duration: time.Millisecond},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sink.SpansCount())
+
+	p.flush(time.Now().Add(defaultDecisionWait), false)
+	assert.Equal(t, 2, sink.SpansCount())
+	assert.ElementsMatch(t, []byte{1, 2}, sampledTraceIDs(sink))
+}
+
+func TestTenantPolicies(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink)
+
+	err := p.ConsumeTraces(context.Background(), generateTraces(bigTenantID,
+		testSpan{traceID: 1, spanID: 1, duration: time.Millisecond, status: pdata.StatusCodeError},
+		testSpan{traceID: 2, spanID: 2, duration: 2 * time.Second},
+		testSpan{traceID: 3, spanID: 3, duration: time.Millisecond},
+	))
+	require.NoError(t, err)
+
+	p.flush(time.Now().Add(defaultDecisionWait), false)
+	assert.ElementsMatch(t, []byte{1, 2}, sampledTraceIDs(sink))
+}
+
+func TestSpansOfTraceAreBufferedTogether(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(bigTenantID,
+		testSpan{traceID: 1, spanID: 1, duration: time.Millisecond},
+	)))
+	// The error arrives in a later batch and must decide for the whole trace.
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(bigTenantID,
+		testSpan{traceID: 1, spanID: 2, duration: time.Millisecond, status: pdata.StatusCodeError},
+	)))
+
+	p.flush(time.Now(), false)
+	assert.Equal(t, 0, sink.SpansCount())
+
+	p.flush(time.Now().Add(defaultDecisionWait), false)
+	assert.Equal(t, 2, sink.SpansCount())
+	assert.Equal(t, 1, len(sink.AllTraces()))
+}
+
+func TestNumTracesLimitEvaluatesOldest(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink)
+	p.numTraces = 1
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(smallTenantID,
+		testSpan{traceID: 1, spanID: 1},
+	)))
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(smallTenantID,
+		testSpan{traceID: 2, spanID: 2},
+	)))
+	assert.Equal(t, []byte{1}, sampledTraceIDs(sink))
+	assert.Equal(t, 1, len(p.traces))
+}
+
+func TestShutdownFlushesBufferedTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTestProcessor(sink)
+	require.NoError(t, p.Start(context.Background(), nil))
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), generateTraces(smallTenantID,
+		testSpan{traceID: 1, spanID: 1},
+	)))
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.SpansCount())
+}
+
+func TestProbabilisticPolicyIsDeterministic(t *testing.T) {
+	all := newPolicyEvaluator(PolicyConfig{Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 100}})
+	none := newPolicyEvaluator(PolicyConfig{Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 0}})
+	half := newPolicyEvaluator(PolicyConfig{Type: Probabilistic, Probabilistic: ProbabilisticConfig{SamplingPercentage: 50}})
+
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		traceID := pdata.NewTraceID([16]byte{byte(i), byte(i >> 8), 3})
+		assert.True(t, all.shouldSample(traceID, nil))
+		assert.False(t, none.shouldSample(traceID, nil))
+		if half.shouldSample(traceID, nil) {
+			sampled++
+			assert.True(t, half.shouldSample(traceID, nil))
+		}
+	}
+	assert.InDelta(t, 500, sampled, 100)
+}
@@ -0,0 +1,119 @@
+package tenantadmissionprocessor
+
+import (
+	"sort"
+	"sync"
+)
+
+// Reasons of a refusal.
+const (
+	reasonLimit       = "limit"
+	reasonTenantLimit = "tenant_limit"
+	reasonFairShare   = "fair_share"
+)
+
+// admission tracks the size of the data in flight in total and per tenant.
+type admission struct {
+	limit       int64
+	softLimit   int64
+	tenantLimit int64
+
+	mu       sync.Mutex
+	inFlight int64
+	tenants  map[string]int64
+}
+
+func newAdmission(limit, softLimit, tenantLimit int64) *admission {
+	return &admission{
+		limit:       limit,
+		softLimit:   softLimit,
+		tenantLimit: tenantLimit,
+		tenants:     map[string]int64{},
+	}
+}
+
+// acquire adds the sizes by tenant to the data in flight. When the data is
+// refused nothing is added and the refused tenant and the reason are returned.
+func (a *admission) acquire(sizes map[string]int64) (string, string, bool) {
+	tenantIDs := make([]string, 0, len(sizes))
+	var size int64
+	for tenantID, s := range sizes {
+		tenantIDs = append(tenantIDs, tenantID)
+		size += s
+	}
+	// Refuse the heaviest tenant of the batch first.
+	sort.Slice(tenantIDs, func(i, j int) bool {
+		if sizes[tenantIDs[i]] != sizes[tenantIDs[j]] {
+			return sizes[tenantIDs[i]] > sizes[tenantIDs[j]]
+		}
+		return tenantIDs[i] < tenantIDs[j]
+	})
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(tenantIDs) == 0 {
+		return "", "", true
+	}
+	if a.inFlight+size > a.limit {
+		return tenantIDs[0], reasonLimit, false
+	}
+	if a.tenantLimit > 0 {
+		for _, tenantID := range tenantIDs {
+			if a.tenants[tenantID]+sizes[tenantID] > a.tenantLimit {
+				return tenantID, reasonTenantLimit, false
+			}
+		}
+	}
+	if a.inFlight+size > a.softLimit {
+		active := int64(len(a.tenants))
+		for _, tenantID := range tenantIDs {
+			if _, ok := a.tenants[tenantID]; !ok {
+				active++
+			}
+		}
+		fairShare := a.softLimit / active
+		for _, tenantID := range tenantIDs {
+			if a.tenants[tenantID]+sizes[tenantID] > fairShare {
+				return tenantID, reasonFairShare, false
+			}
+		}
+	}
+
+	a.addLocked(sizes)
+	return "", "", true
+}
+
+// add adds the sizes by tenant to the data in flight without checking the limits.
+func (a *admission) add(sizes map[string]int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.addLocked(sizes)
+}
+
+func (a *admission) addLocked(sizes map[string]int64) {
+	for tenantID, s := range sizes {
+		a.tenants[tenantID] += s
+		a.inFlight += s
+	}
+}
+
+// release removes the sizes by tenant acquired before from the data in flight.
+func (a *admission) release(sizes map[string]int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for tenantID, s := range sizes {
+		a.inFlight -= s
+		if a.tenants[tenantID] -= s; a.tenants[tenantID] <= 0 {
+			delete(a.tenants, tenantID)
+		}
+	}
+}
+
+// tenantInFlight returns the size of the data in flight of the tenant.
+func (a *admission) tenantInFlight(tenantID string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.tenants[tenantID]
+}
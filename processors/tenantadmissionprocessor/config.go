@@ -0,0 +1,73 @@
+package tenantadmissionprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for tenant admission processor.
+// The processor protects the collector memory by limiting the size of the
+// data in flight, i.e. the data handed to the next consumer which has not
+// returned yet. The size is the OTLP encoded size of the data, it is split
+// between the tenants of a batch in proportion to their spans or metrics.
+//
+// Data is refused with a retryable error when:
+//   - the data in flight would exceed limit_mib,
+//   - the data in flight of the tenant would exceed tenant_limit_mib,
+//   - the data in flight would exceed the soft limit and the tenant would
+//     use more than its fair share of it, i.e. the soft limit divided by the
+//     number of tenants with data in flight. The heaviest tenants are refused
+//     first while the lighter ones are still admitted.
+//
+// A batch is admitted or refused as a whole: a batch holding data of several
+// tenants is refused when one of them is refused. Receivers pass the data of
+// a request as one batch, which usually belongs to a single tenant.
+//
+// The tenant of the data is taken from the tenant ID attribute, which is set by
+// the hypertrace_tenantid processor, or else from the tenant ID header of the
+// request. The processor must be placed after the hypertrace_tenantid processor
+// and before the batch processor:
+//
+//	processors: [hypertrace_tenantid, hypertrace_tenantadmission, batch]
+//
+// The batch processor drops the data refused by the following processors
+// instead of returning the error to the clients. Data not coming from a
+// receiver through synchronous processors is always admitted and an error is
+// logged. The limits apply to each pipeline using the processor
+// and the data is in flight until the rest of the pipeline returns, e.g. until
+// it is queued by the batch processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute and resource attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// TenantIDHeaderName defines tenant HTTP header name used for data without
+	// the tenant ID attribute. Default x-tenant-id.
+	TenantIDHeaderName string `mapstructure:"header_name"`
+	// LimitMiB is the maximum size in MiB of the data in flight.
+	LimitMiB uint64 `mapstructure:"limit_mib"`
+	// SoftLimitPercentage is the percentage of limit_mib above which tenants
+	// using more than their fair share are refused. Default 80.
+	SoftLimitPercentage uint64 `mapstructure:"soft_limit_percentage"`
+	// TenantLimitMiB is the maximum size in MiB of the data in flight of a
+	// single tenant, 0 disables the limit.
+	TenantLimitMiB uint64 `mapstructure:"tenant_limit_mib"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.LimitMiB == 0 {
+		return errors.New("limit_mib must be greater than 0")
+	}
+	if cfg.SoftLimitPercentage == 0 || cfg.SoftLimitPercentage > 100 {
+		return fmt.Errorf("soft_limit_percentage must be between 1 and 100, got %d", cfg.SoftLimitPercentage)
+	}
+	if cfg.TenantLimitMiB > cfg.LimitMiB {
+		return fmt.Errorf("tenant_limit_mib must not be greater than limit_mib, got %d", cfg.TenantLimitMiB)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package tenantadmissionprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	pCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, pCfg.TenantIDAttributeKey)
+	assert.Equal(t, uint64(1536), pCfg.LimitMiB)
+	assert.Equal(t, uint64(75), pCfg.SoftLimitPercentage)
+	assert.Equal(t, uint64(512), pCfg.TenantLimitMiB)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+
+	cfg.LimitMiB = 1024
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SoftLimitPercentage = 101
+	assert.Error(t, cfg.Validate())
+	cfg.SoftLimitPercentage = 0
+	assert.Error(t, cfg.Validate())
+	cfg.SoftLimitPercentage = 100
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TenantLimitMiB = 2048
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,61 @@
+package tenantadmissionprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_tenantadmission"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultHeaderName           = "x-tenant-id"
+	defaultSoftLimitPercentage  = 80
+)
+
+// NewFactory creates a factory for the tenant admission processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		TenantIDHeaderName:   defaultHeaderName,
+		SoftLimitPercentage:  defaultSoftLimitPercentage,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	p := newProcessor(params.Logger, cfg.(*Config))
+	p.nextTraces = nextConsumer
+	return p, nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	p := newProcessor(params.Logger, cfg.(*Config))
+	p.nextMetrics = nextConsumer
+	return p, nil
+}
@@ -0,0 +1,49 @@
+package tenantadmissionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultHeaderName, cfg.TenantIDHeaderName)
+	assert.Zero(t, cfg.LimitMiB)
+	assert.Equal(t, uint64(defaultSoftLimitPercentage), cfg.SoftLimitPercentage)
+	assert.Zero(t, cfg.TenantLimitMiB)
+}
+
+func TestCreateTraceProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LimitMiB = 1024
+	tp, err := factory.CreateTracesProcessor(
+		context.Background(),
+		component.ProcessorCreateSettings{Logger: zap.NewNop()},
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, tp)
+}
+
+func TestCreateMetricsProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LimitMiB = 1024
+	mp, err := factory.CreateMetricsProcessor(
+		context.Background(),
+		component.ProcessorCreateSettings{Logger: zap.NewNop()},
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+}
@@ -0,0 +1,49 @@
+package tenantadmissionprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagReason   = tag.MustNewKey("reason")
+
+	statInFlightBytes       = stats.Int64("tenant_admission_in_flight_bytes", "Size of the data in flight of the tenant", stats.UnitBytes)
+	statRefusedBytes        = stats.Int64("tenant_admission_refused_bytes", "Size of the data of the tenant refused by the admission", stats.UnitBytes)
+	statRefusedRequestCount = stats.Int64("tenant_admission_refused_request_count", "Number of requests containing data of the tenant refused by the admission", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for tenant admission processor.
+func MetricViews() []*view.View {
+	viewInFlightBytes := &view.View{
+		Name:        statInFlightBytes.Name(),
+		Description: statInFlightBytes.Description(),
+		Measure:     statInFlightBytes,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	viewRefusedBytes := &view.View{
+		Name:        statRefusedBytes.Name(),
+		Description: statRefusedBytes.Description(),
+		Measure:     statRefusedBytes,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagReason},
+	}
+
+	viewRefusedRequestCount := &view.View{
+		Name:        statRefusedRequestCount.Name(),
+		Description: statRefusedRequestCount.Description(),
+		Measure:     statRefusedRequestCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagReason},
+	}
+
+	return []*view.View{
+		viewInFlightBytes,
+		viewRefusedBytes,
+		viewRefusedRequestCount,
+	}
+}
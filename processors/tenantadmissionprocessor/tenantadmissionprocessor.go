@@ -0,0 +1,204 @@
+package tenantadmissionprocessor
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const mib = 1024 * 1024
+
+// tagReceiver is set by the receivers on the context of the received data.
+var tagReceiver = tag.MustNewKey("receiver")
+
+type processor struct {
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	tenantIDHeaderName   string
+	admission            *admission
+	nextTraces           consumer.Traces
+	nextMetrics          consumer.Metrics
+
+	// misplaced logs once that the data does not come directly from a receiver.
+	misplaced sync.Once
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+var _ component.MetricsProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config) *processor {
+	limit := int64(cfg.LimitMiB) * mib
+	return &processor{
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		tenantIDHeaderName:   cfg.TenantIDHeaderName,
+		admission: newAdmission(
+			limit,
+			limit*int64(cfg.SoftLimitPercentage)/100,
+			int64(cfg.TenantLimitMiB)*mib),
+	}
+}
+
+// Start implements component.Component
+func (p *processor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown implements component.Component
+func (p *processor) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements consumer.Traces and consumer.Metrics
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces sends the traces to the next consumer when they are admitted.
+func (p *processor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	headerTenantID := p.headerTenantID(ctx)
+	counts := map[string]int64{}
+	var total int64
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID := p.resourceTenantID(rs.Resource(), headerTenantID)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				tenantID := resourceTenantID
+				if attr, ok := spans.At(k).Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				counts[tenantID]++
+				total++
+			}
+		}
+	}
+
+	sizes := splitSize(int64(td.OtlpProtoSize()), counts, total)
+	if err := p.acquire(ctx, sizes); err != nil {
+		return err
+	}
+	defer p.release(ctx, sizes)
+	return p.nextTraces.ConsumeTraces(ctx, td)
+}
+
+// ConsumeMetrics sends the metrics to the next consumer when they are admitted.
+func (p *processor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	headerTenantID := p.headerTenantID(ctx)
+	counts := map[string]int64{}
+	var total int64
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenantID := p.resourceTenantID(rm.Resource(), headerTenantID)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			n := int64(ilms.At(j).Metrics().Len())
+			counts[tenantID] += n
+			total += n
+		}
+	}
+
+	sizes := splitSize(int64(md.OtlpProtoSize()), counts, total)
+	if err := p.acquire(ctx, sizes); err != nil {
+		return err
+	}
+	defer p.release(ctx, sizes)
+	return p.nextMetrics.ConsumeMetrics(ctx, md)
+}
+
+func (p *processor) resourceTenantID(resource pdata.Resource, headerTenantID string) string {
+	if attr, ok := resource.Attributes().Get(p.tenantIDAttributeKey); ok {
+		return attr.StringVal()
+	}
+	return headerTenantID
+}
+
+// headerTenantID returns the tenant ID from the request metadata or an empty
+// string if there is not exactly one tenant ID header.
+func (p *processor) headerTenantID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tenantIDs := md.Get(p.tenantIDHeaderName); len(tenantIDs) == 1 {
+		return tenantIDs[0]
+	}
+	return ""
+}
+
+// splitSize splits the size between the tenants in proportion to their counts,
+// the rounding remainder is given to the first tenants in map order.
+func splitSize(size int64, counts map[string]int64, total int64) map[string]int64 {
+	sizes := map[string]int64{}
+	if total == 0 {
+		return sizes
+	}
+	remainder := size
+	for tenantID, count := range counts {
+		sizes[tenantID] = size * count / total
+		remainder -= sizes[tenantID]
+	}
+	for tenantID := range sizes {
+		if remainder == 0 {
+			break
+		}
+		sizes[tenantID]++
+		remainder--
+	}
+	return sizes
+}
+
+// acquire admits the data or returns a retryable error so that the clients back off.
+func (p *processor) acquire(ctx context.Context, sizes map[string]int64) error {
+	refusedTenantID, reason, ok := p.admission.acquire(sizes)
+	if ok {
+		p.recordInFlight(ctx, sizes)
+		return nil
+	}
+	if _, fromReceiver := tag.FromContext(ctx).Value(tagReceiver); !fromReceiver {
+		// The error would not reach the client and the data would be lost.
+		p.misplaced.Do(func() {
+			p.logger.Error("Data is not refused since it does not come directly from a receiver, " +
+				"the processor must be placed after the hypertrace_tenantid processor and before the batch processor")
+		})
+		p.admission.add(sizes)
+		p.recordInFlight(ctx, sizes)
+		return nil
+	}
+
+	p.logger.Debug("Refused data",
+		zap.String("tenant", refusedTenantID),
+		zap.String("reason", reason))
+	for tenantID, size := range sizes {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID),
+			tag.Insert(tagReason, reason))
+		stats.Record(tCtx, statRefusedBytes.M(size), statRefusedRequestCount.M(1))
+	}
+	return status.Errorf(codes.Unavailable, "data of tenant %q refused due to high memory usage (%s)", refusedTenantID, reason)
+}
+
+func (p *processor) release(ctx context.Context, sizes map[string]int64) {
+	p.admission.release(sizes)
+	p.recordInFlight(ctx, sizes)
+}
+
+func (p *processor) recordInFlight(ctx context.Context, sizes map[string]int64) {
+	for tenantID := range sizes {
+		tCtx, _ := tag.New(ctx, tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statInFlightBytes.M(p.admission.tenantInFlight(tenantID)))
+	}
+}
@@ -0,0 +1,207 @@
+package tenantadmissionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdmission(t *testing.T) {
+	a := newAdmission(100, 70, 60)
+
+	// Below the soft limit every tenant is admitted.
+	_, _, ok := a.acquire(map[string]int64{"jdoe": 50})
+	require.True(t, ok)
+	_, _, ok = a.acquire(map[string]int64{"acme": 20})
+	require.True(t, ok)
+
+	// Above the tenant limit.
+	tenantID, reason, ok := a.acquire(map[string]int64{"jdoe": 15})
+	assert.False(t, ok)
+	assert.Equal(t, "jdoe", tenantID)
+	assert.Equal(t, reasonTenantLimit, reason)
+
+	// Above the soft limit the fair share is 70/2, jdoe is refused first.
+	tenantID, reason, ok = a.acquire(map[string]int64{"jdoe": 5})
+	assert.False(t, ok)
+	assert.Equal(t, "jdoe", tenantID)
+	assert.Equal(t, reasonFairShare, reason)
+	_, _, ok = a.acquire(map[string]int64{"trial": 5})
+	assert.True(t, ok)
+
+	// Above the limit every tenant is refused.
+	tenantID, reason, ok = a.acquire(map[string]int64{"trial": 1, "other": 30})
+	assert.False(t, ok)
+	assert.Equal(t, "other", tenantID)
+	assert.Equal(t, reasonLimit, reason)
+
+	// Refused data is not added.
+	assert.Equal(t, int64(75), a.inFlight)
+	assert.Equal(t, int64(50), a.tenantInFlight("jdoe"))
+
+	a.release(map[string]int64{"jdoe": 50})
+	a.release(map[string]int64{"acme": 20})
+	a.release(map[string]int64{"trial": 5})
+	assert.Zero(t, a.inFlight)
+	assert.Empty(t, a.tenants)
+}
+
+func TestSplitSize(t *testing.T) {
+	sizes := splitSize(100, map[string]int64{"jdoe": 2, "acme": 1}, 3)
+	assert.Equal(t, int64(100), sizes["jdoe"]+sizes["acme"])
+	assert.GreaterOrEqual(t, sizes["jdoe"], int64(66))
+	assert.GreaterOrEqual(t, sizes["acme"], int64(33))
+
+	assert.Empty(t, splitSize(100, map[string]int64{}, 0))
+}
+
+func newTestTraces(tenantID string, spanCount int) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i := 0; i < spanCount; i++ {
+		spans.AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func withPayload(td pdata.Traces, size int) pdata.Traces {
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Attributes().InsertString("payload", string(make([]byte, size)))
+	return td
+}
+
+// reentrantTraces sends traces to the processor while the first traces are in flight.
+type reentrantTraces struct {
+	consumer.Traces
+	sink  *consumertest.TracesSink
+	inner func(context.Context) error
+	err   error
+}
+
+func (r *reentrantTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if r.inner != nil {
+		inner := r.inner
+		r.inner = nil
+		r.err = inner(ctx)
+	}
+	return r.sink.ConsumeTraces(ctx, td)
+}
+
+// receiverContext returns the context of data received by a receiver.
+func receiverContext() context.Context {
+	return obsreport.ReceiverContext(context.Background(), config.NewID("otlp"), "grpc")
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LimitMiB = 1
+	p := newProcessor(zap.NewNop(), cfg)
+
+	heavy := withPayload(newTestTraces("jdoe", 1), 800*1024)
+
+	next := &reentrantTraces{sink: new(consumertest.TracesSink)}
+	next.inner = func(ctx context.Context) error {
+		// Above the soft limit jdoe uses more than its fair share, acme is still admitted.
+		require.NoError(t, p.ConsumeTraces(ctx, withPayload(newTestTraces("acme", 10), 40*1024)))
+		return p.ConsumeTraces(ctx, withPayload(newTestTraces("jdoe", 10), 40*1024))
+	}
+	p.nextTraces = next
+
+	require.NoError(t, p.ConsumeTraces(receiverContext(), heavy))
+	require.Error(t, next.err)
+	assert.False(t, consumererror.IsPermanent(next.err))
+	assert.Equal(t, codes.Unavailable, status.Code(next.err))
+	assert.Equal(t, 11, next.sink.SpansCount())
+
+	// The data is released once consumed.
+	assert.Zero(t, p.admission.inFlight)
+	require.NoError(t, p.ConsumeTraces(receiverContext(), newTestTraces("jdoe", 10)))
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LimitMiB = 1
+	p := newProcessor(zap.NewNop(), cfg)
+	sink := new(consumertest.MetricsSink)
+	p.nextMetrics = sink
+
+	md := pdata.NewMetrics()
+	for _, tenantID := range []string{"jdoe", "acme"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	}
+	require.NoError(t, p.ConsumeMetrics(receiverContext(), md))
+	assert.Equal(t, 2, sink.MetricsCount())
+	assert.Zero(t, p.admission.inFlight)
+
+	// A batch above the limit is refused.
+	p.admission.limit = int64(md.OtlpProtoSize()) - 1
+	err := p.ConsumeMetrics(receiverContext(), md)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, sink.MetricsCount())
+}
+
+func TestDataNotFromReceiverIsAdmitted(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LimitMiB = 1
+	p := newProcessor(zap.NewNop(), cfg)
+	sink := new(consumertest.TracesSink)
+	p.nextTraces = sink
+
+	td := withPayload(newTestTraces("jdoe", 1), 2*mib)
+	assert.Error(t, p.ConsumeTraces(receiverContext(), td))
+	// After the batch processor the refusal would be dropped.
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sink.SpansCount())
+	assert.Zero(t, p.admission.inFlight)
+}
+
+// inFlightTraces records the data in flight of the tenants while consuming traces.
+type inFlightTraces struct {
+	consumertest.TracesSink
+	p        *processor
+	inFlight map[string]int64
+}
+
+func (c *inFlightTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	for _, tenantID := range []string{"", "jdoe"} {
+		c.inFlight[tenantID] = c.p.admission.tenantInFlight(tenantID)
+	}
+	return c.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestDataWithoutTenantAttribute(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LimitMiB = 1
+	p := newProcessor(zap.NewNop(), cfg)
+	next := &inFlightTraces{p: p, inFlight: map[string]int64{}}
+	p.nextTraces = next
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	size := int64(td.OtlpProtoSize())
+
+	// The tenant is taken from the header when the tenant ID is not set yet.
+	ctx := metadata.NewIncomingContext(receiverContext(), metadata.Pairs(defaultHeaderName, "jdoe"))
+	require.NoError(t, p.ConsumeTraces(ctx, td))
+	assert.Equal(t, map[string]int64{"": 0, "jdoe": size}, next.inFlight)
+
+	require.NoError(t, p.ConsumeTraces(receiverContext(), td))
+	assert.Equal(t, map[string]int64{"": size, "jdoe": 0}, next.inFlight)
+	assert.Equal(t, 2, next.SpansCount())
+}
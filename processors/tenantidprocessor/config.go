@@ -17,4 +17,10 @@ type Config struct {
 	TenantIDHeaderName string `mapstructure:"header_name"`
 	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
 	TenantIDAttributeKey string `mapstructure:"attribute_key"`
+	// ScrubHeader removes the tenant ID header from the context metadata passed
+	// to the next consumer, so exporters do not propagate it. Default false.
+	ScrubHeader bool `mapstructure:"scrub_header"`
+	// ScrubAdditionalHeaders are removed together with the tenant ID header,
+	// e.g. the token the tenant ID was derived from.
+	ScrubAdditionalHeaders []string `mapstructure:"scrub_additional_headers"`
 }
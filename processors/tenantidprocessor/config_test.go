@@ -24,4 +24,6 @@ func TestLoadConfig(t *testing.T) {
 	tIDcfg := cfg.Processors[config.NewID(typeStr)].(*Config)
 	assert.Equal(t, "header-tenant", tIDcfg.TenantIDHeaderName)
 	assert.Equal(t, "attribute-tenant", tIDcfg.TenantIDAttributeKey)
+	assert.True(t, tIDcfg.ScrubHeader)
+	assert.Equal(t, []string{"authorization"}, tIDcfg.ScrubAdditionalHeaders)
 }
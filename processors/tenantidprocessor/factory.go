@@ -43,6 +43,9 @@ func createTraceProcessor(
 	nextConsumer consumer.Traces,
 ) (component.TracesProcessor, error) {
 	pCfg := cfg.(*Config)
+	if pCfg.ScrubHeader {
+		nextConsumer = tracesScrubber{next: nextConsumer, headers: scrubbedHeaders(pCfg)}
+	}
 	return processorhelper.NewTracesProcessor(
 		cfg,
 		nextConsumer,
@@ -60,6 +63,9 @@ func createMetricsProcessor(
 	nextConsumer consumer.Metrics,
 ) (component.MetricsProcessor, error) {
 	pCfg := cfg.(*Config)
+	if pCfg.ScrubHeader {
+		nextConsumer = metricsScrubber{next: nextConsumer, headers: scrubbedHeaders(pCfg)}
+	}
 	return processorhelper.NewMetricsProcessor(
 		cfg,
 		nextConsumer,
@@ -69,3 +75,7 @@ func createMetricsProcessor(
 			logger:               params.Logger,
 		})
 }
+
+func scrubbedHeaders(cfg *Config) []string {
+	return append([]string{cfg.TenantIDHeaderName}, cfg.ScrubAdditionalHeaders...)
+}
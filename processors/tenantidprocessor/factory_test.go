@@ -10,4 +10,5 @@ func TestCreateDefaultConfig(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	assert.Equal(t, defaultHeaderName, cfg.TenantIDHeaderName)
 	assert.Equal(t, defaultAttributeKey, cfg.TenantIDAttributeKey)
+	assert.False(t, cfg.ScrubHeader)
 }
@@ -8,33 +8,38 @@ import (
 
 var (
 	tagTenantID = tag.MustNewKey("tenant-id")
+	// tagReceiver and tagTransport are set by the receivers on the context of the received data.
+	tagReceiver  = tag.MustNewKey("receiver")
+	tagTransport = tag.MustNewKey("transport")
 
-	statSpanPerTenant   = stats.Int64("tenant_id_span_count", "Number of spans received from a tenant", stats.UnitDimensionless)
-	statMetricPerTenant = stats.Int64("tenant_id_metric_count", "Number of metrics received from a tenant", stats.UnitDimensionless)
+	statSpanPerTenant          = stats.Int64("tenant_id_span_count", "Number of spans received from a tenant", stats.UnitDimensionless)
+	statMetricPerTenant        = stats.Int64("tenant_id_metric_count", "Number of metrics received from a tenant", stats.UnitDimensionless)
+	statSpanBytesPerTenant     = stats.Int64("tenant_id_span_bytes", "Size in OTLP encoding of the spans received from a tenant", stats.UnitBytes)
+	statMetricBytesPerTenant   = stats.Int64("tenant_id_metric_bytes", "Size in OTLP encoding of the metrics received from a tenant", stats.UnitBytes)
+	statSpanRequestPerTenant   = stats.Int64("tenant_id_span_request_count", "Number of span requests received from a tenant", stats.UnitDimensionless)
+	statMetricRequestPerTenant = stats.Int64("tenant_id_metric_request_count", "Number of metric requests received from a tenant", stats.UnitDimensionless)
 )
 
 // MetricViews returns the metrics views for tenant id processor.
 func MetricViews() []*view.View {
-	tags := []tag.Key{tagTenantID}
+	tags := []tag.Key{tagTenantID, tagReceiver, tagTransport}
 
-	viewSpanCount := &view.View{
-		Name:        statSpanPerTenant.Name(),
-		Description: statSpanPerTenant.Description(),
-		Measure:     statSpanPerTenant,
-		Aggregation: view.Sum(),
-		TagKeys:     tags,
-	}
-
-	viewMetricCount := &view.View{
-		Name:        statMetricPerTenant.Name(),
-		Description: statMetricPerTenant.Description(),
-		Measure:     statMetricPerTenant,
-		Aggregation: view.Sum(),
-		TagKeys:     tags,
-	}
-
-	return []*view.View{
-		viewSpanCount,
-		viewMetricCount,
+	var views []*view.View
+	for _, measure := range []*stats.Int64Measure{
+		statSpanPerTenant,
+		statMetricPerTenant,
+		statSpanBytesPerTenant,
+		statMetricBytesPerTenant,
+		statSpanRequestPerTenant,
+		statMetricRequestPerTenant,
+	} {
+		views = append(views, &view.View{
+			Name:        measure.Name(),
+			Description: measure.Description(),
+			Measure:     measure,
+			Aggregation: view.Sum(),
+			TagKeys:     tags,
+		})
 	}
+	return views
 }
@@ -0,0 +1,66 @@
+package tenantidprocessor
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"google.golang.org/grpc/metadata"
+)
+
+// tracesScrubber removes headers from the context metadata before passing the traces on.
+type tracesScrubber struct {
+	next    consumer.Traces
+	headers []string
+}
+
+var _ consumer.Traces = (*tracesScrubber)(nil)
+
+// Capabilities implements consumer.Traces
+func (s tracesScrubber) Capabilities() consumer.Capabilities {
+	return s.next.Capabilities()
+}
+
+// ConsumeTraces implements consumer.Traces
+func (s tracesScrubber) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return s.next.ConsumeTraces(scrubHeaders(ctx, s.headers), td)
+}
+
+// metricsScrubber removes headers from the context metadata before passing the metrics on.
+type metricsScrubber struct {
+	next    consumer.Metrics
+	headers []string
+}
+
+var _ consumer.Metrics = (*metricsScrubber)(nil)
+
+// Capabilities implements consumer.Metrics
+func (s metricsScrubber) Capabilities() consumer.Capabilities {
+	return s.next.Capabilities()
+}
+
+// ConsumeMetrics implements consumer.Metrics
+func (s metricsScrubber) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return s.next.ConsumeMetrics(scrubHeaders(ctx, s.headers), md)
+}
+
+// scrubHeaders returns a context with the headers removed from the incoming and
+// outgoing metadata. The metadata of ctx is not modified.
+func scrubHeaders(ctx context.Context, headers []string) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewIncomingContext(ctx, withoutHeaders(md, headers))
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, withoutHeaders(md, headers))
+	}
+	return ctx
+}
+
+func withoutHeaders(md metadata.MD, headers []string) metadata.MD {
+	md = md.Copy()
+	for _, header := range headers {
+		delete(md, strings.ToLower(header))
+	}
+	return md
+}
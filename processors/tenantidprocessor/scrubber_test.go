@@ -0,0 +1,93 @@
+package tenantidprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// contextSink records the context passed by the previous consumer.
+type contextSink struct {
+	ctx context.Context
+}
+
+func (s *contextSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (s *contextSink) ConsumeTraces(ctx context.Context, _ pdata.Traces) error {
+	s.ctx = ctx
+	return nil
+}
+
+func (s *contextSink) ConsumeMetrics(ctx context.Context, _ pdata.Metrics) error {
+	s.ctx = ctx
+	return nil
+}
+
+func newScrubTestContext() context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		defaultHeaderName: testTenantID,
+		"authorization":   "Bearer token",
+		"x-request-id":    "abc",
+	}))
+}
+
+func assertScrubbed(t *testing.T, ctx context.Context) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	require.True(t, ok)
+	assert.Empty(t, md.Get(defaultHeaderName))
+	assert.Empty(t, md.Get("authorization"))
+	assert.Equal(t, []string{"abc"}, md.Get("x-request-id"))
+}
+
+func TestScrubHeaderTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ScrubHeader = true
+	cfg.ScrubAdditionalHeaders = []string{"Authorization"}
+
+	sink := &contextSink{}
+	p, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+
+	ctx := newScrubTestContext()
+	require.NoError(t, p.ConsumeTraces(ctx, generateTraceDataOneSpan()))
+	assertScrubbed(t, sink.ctx)
+
+	// The context of the caller is not modified.
+	md, _ := metadata.FromIncomingContext(ctx)
+	assert.Equal(t, []string{testTenantID}, md.Get(defaultHeaderName))
+}
+
+func TestScrubHeaderMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ScrubHeader = true
+	cfg.ScrubAdditionalHeaders = []string{"authorization"}
+
+	sink := &contextSink{}
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateSettings{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeMetrics(newScrubTestContext(), generateMetricData()))
+	assertScrubbed(t, sink.ctx)
+}
+
+func TestNoScrubHeader(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	sink := &contextSink{}
+	p, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeTraces(newScrubTestContext(), generateTraceDataOneSpan()))
+	md, ok := metadata.FromIncomingContext(sink.ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{testTenantID}, md.Get(defaultHeaderName))
+}
@@ -38,11 +38,16 @@ func (p *processor) ProcessMetrics(ctx context.Context, metrics pdata.Metrics) (
 	}
 
 	tenantID := tenantIDHeaders[0]
+	// The size is taken before adding the tenant ID to measure the received data.
+	size := metrics.OtlpProtoSize()
 	p.addTenantIdToMetrics(metrics, tenantID)
 
 	ctx, _ = tag.New(ctx,
 		tag.Insert(tagTenantID, tenantID))
-	stats.Record(ctx, statMetricPerTenant.M(int64(metrics.MetricCount())))
+	stats.Record(ctx,
+		statMetricPerTenant.M(int64(metrics.MetricCount())),
+		statMetricBytesPerTenant.M(int64(size)),
+		statMetricRequestPerTenant.M(1))
 
 	return metrics, nil
 
@@ -63,11 +68,16 @@ func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pda
 	}
 
 	tenantID := tenantIDHeaders[0]
+	// The size is taken before adding the tenant ID to measure the received data.
+	size := traces.OtlpProtoSize()
 	p.addTenantIdToSpans(traces, tenantID)
 
 	ctx, _ = tag.New(ctx,
 		tag.Insert(tagTenantID, tenantID))
-	stats.Record(ctx, statSpanPerTenant.M(int64(traces.SpanCount())))
+	stats.Record(ctx,
+		statSpanPerTenant.M(int64(traces.SpanCount())),
+		statSpanBytesPerTenant.M(int64(size)),
+		statSpanRequestPerTenant.M(1))
 
 	return traces, nil
 }
@@ -18,6 +18,8 @@ import (
 	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -27,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver/jaegerreceiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/testutil"
@@ -550,3 +553,42 @@ func sendToJaegerHTTPThrift(endpoint string, headers map[string]string, batch *j
 	}
 	return nil
 }
+
+func TestMetricViewsTags(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	p := &processor{
+		logger:               zap.NewNop(),
+		tenantIDHeaderName:   defaultHeaderName,
+		tenantIDAttributeKey: defaultAttributeKey,
+	}
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.New(map[string]string{defaultHeaderName: testTenantID}),
+	)
+	ctx = obsreport.ReceiverContext(ctx, config.NewID("zipkin"), "http_v2_json")
+
+	td := generateTraceDataOneSpan()
+	size := td.OtlpProtoSize()
+	_, err := p.ProcessTraces(ctx, td)
+	require.NoError(t, err)
+
+	expected := map[string]int64{
+		statSpanPerTenant.Name():        1,
+		statSpanBytesPerTenant.Name():   int64(size),
+		statSpanRequestPerTenant.Name(): 1,
+	}
+	for name, value := range expected {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		require.Len(t, rows, 1, name)
+		assert.ElementsMatch(t, []tag.Tag{
+			{Key: tagTenantID, Value: testTenantID},
+			{Key: tagReceiver, Value: "zipkin"},
+			{Key: tagTransport, Value: "http_v2_json"},
+		}, rows[0].Tags, name)
+		assert.Equal(t, float64(value), rows[0].Data.(*view.SumData).Value, name)
+	}
+}
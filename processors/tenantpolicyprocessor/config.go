@@ -0,0 +1,50 @@
+package tenantpolicyprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for tenant policy processor.
+// The processor applies the policy of the tenant to its spans and metrics.
+// Policies are read from <tenant ID>.yml or <tenant ID>.yaml files in the
+// policy directory, tenants without a policy file are not changed.
+//
+// A policy file lists the attribute keys to keep, drop and hash and the
+// names of the spans to drop:
+//
+//	keep: [http.method, http.status_code, http.route]
+//	drop: [http.request.body]
+//	hash: [enduser.id]
+//	drop_span_names: ["GET /health.*"]
+//
+// When keep is not empty, all other keys except the hashed ones are dropped.
+// Span names are regular expressions matching the whole name. The keys apply
+// to span attributes and metric labels, the tenant ID is never changed.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute and metric label key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// PolicyDirectory is the directory containing the policy files.
+	PolicyDirectory string `mapstructure:"policy_directory"`
+	// ReloadInterval is the period in which the policy directory is checked for
+	// changes and the policies are reloaded, 0 disables reloading. Default 1m.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.PolicyDirectory == "" {
+		return errors.New("policy_directory is required")
+	}
+	if cfg.ReloadInterval < 0 {
+		return fmt.Errorf("reload_interval must not be negative, got %s", cfg.ReloadInterval)
+	}
+	return nil
+}
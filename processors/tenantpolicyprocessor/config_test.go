@@ -0,0 +1,40 @@
+package tenantpolicyprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	pCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, pCfg.TenantIDAttributeKey)
+	assert.Equal(t, "testdata/policies", pCfg.PolicyDirectory)
+	assert.Equal(t, 30*time.Second, pCfg.ReloadInterval)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+
+	cfg.PolicyDirectory = "testdata/policies"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ReloadInterval = -time.Second
+	assert.Error(t, cfg.Validate())
+}
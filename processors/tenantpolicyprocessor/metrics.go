@@ -0,0 +1,57 @@
+package tenantpolicyprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagAction   = tag.MustNewKey("action")
+	tagResult   = tag.MustNewKey("result")
+
+	statDroppedSpanCount  = stats.Int64("tenant_policy_dropped_span_count", "Number of spans dropped by the policy of the tenant", stats.UnitDimensionless)
+	statChangedKeyCount   = stats.Int64("tenant_policy_changed_key_count", "Number of span attributes and metric labels dropped or hashed by the policy of the tenant", stats.UnitDimensionless)
+	statPolicyReloadCount = stats.Int64("tenant_policy_reload_count", "Number of policy file loads", stats.UnitDimensionless)
+)
+
+const (
+	actionDrop    = "drop"
+	actionHash    = "hash"
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// MetricViews returns the metrics views for tenant policy processor.
+func MetricViews() []*view.View {
+	viewDroppedSpanCount := &view.View{
+		Name:        statDroppedSpanCount.Name(),
+		Description: statDroppedSpanCount.Description(),
+		Measure:     statDroppedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID},
+	}
+
+	viewChangedKeyCount := &view.View{
+		Name:        statChangedKeyCount.Name(),
+		Description: statChangedKeyCount.Description(),
+		Measure:     statChangedKeyCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagAction},
+	}
+
+	viewPolicyReloadCount := &view.View{
+		Name:        statPolicyReloadCount.Name(),
+		Description: statPolicyReloadCount.Description(),
+		Measure:     statPolicyReloadCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagResult},
+	}
+
+	return []*view.View{
+		viewDroppedSpanCount,
+		viewChangedKeyCount,
+		viewPolicyReloadCount,
+	}
+}
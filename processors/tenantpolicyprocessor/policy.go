@@ -0,0 +1,142 @@
+package tenantpolicyprocessor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"gopkg.in/yaml.v2"
+)
+
+// policyFile is the content of a policy file.
+type policyFile struct {
+	Keep          []string `yaml:"keep"`
+	Drop          []string `yaml:"drop"`
+	Hash          []string `yaml:"hash"`
+	DropSpanNames []string `yaml:"drop_span_names"`
+}
+
+type policy struct {
+	keep          map[string]bool
+	drop          map[string]bool
+	hash          map[string]bool
+	dropSpanNames []*regexp.Regexp
+}
+
+// keyChanges counts the dropped and hashed keys.
+type keyChanges struct {
+	dropped int64
+	hashed  int64
+}
+
+func parsePolicy(data []byte) (*policy, error) {
+	var f policyFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, err
+	}
+
+	p := &policy{keep: keySet(f.Keep), drop: keySet(f.Drop), hash: keySet(f.Hash)}
+	for _, name := range f.DropSpanNames {
+		re, err := regexp.Compile("^(?:" + name + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid span name %q: %w", name, err)
+		}
+		p.dropSpanNames = append(p.dropSpanNames, re)
+	}
+	return p, nil
+}
+
+func keySet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// action returns the action applied to the key, an empty string if the key is kept as it is.
+func (p *policy) action(key string) string {
+	switch {
+	case p.drop[key]:
+		return actionDrop
+	case p.hash[key]:
+		return actionHash
+	case len(p.keep) > 0 && !p.keep[key]:
+		return actionDrop
+	}
+	return ""
+}
+
+func (p *policy) dropSpan(name string) bool {
+	for _, re := range p.dropSpanNames {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyAttributes drops and hashes the attributes, except the tenant ID.
+func (p *policy) applyAttributes(attrs pdata.AttributeMap, tenantIDKey string, changes *keyChanges) {
+	var dropKeys, hashKeys []string
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		if k == tenantIDKey {
+			return true
+		}
+		switch p.action(k) {
+		case actionDrop:
+			dropKeys = append(dropKeys, k)
+		case actionHash:
+			hashKeys = append(hashKeys, k)
+		}
+		return true
+	})
+	for _, k := range dropKeys {
+		attrs.Delete(k)
+	}
+	for _, k := range hashKeys {
+		v, _ := attrs.Get(k)
+		value := v.StringVal()
+		if v.Type() != pdata.AttributeValueTypeString {
+			value = tracetranslator.AttributeValueToString(v)
+		}
+		attrs.UpsertString(k, hash(value))
+	}
+	changes.dropped += int64(len(dropKeys))
+	changes.hashed += int64(len(hashKeys))
+}
+
+// applyLabels drops and hashes the labels, except the tenant ID.
+func (p *policy) applyLabels(labels pdata.StringMap, tenantIDKey string, changes *keyChanges) {
+	var dropKeys, hashKeys []string
+	labels.Range(func(k string, _ string) bool {
+		if k == tenantIDKey {
+			return true
+		}
+		switch p.action(k) {
+		case actionDrop:
+			dropKeys = append(dropKeys, k)
+		case actionHash:
+			hashKeys = append(hashKeys, k)
+		}
+		return true
+	})
+	for _, k := range dropKeys {
+		labels.Delete(k)
+	}
+	for _, k := range hashKeys {
+		v, _ := labels.Get(k)
+		labels.Upsert(k, hash(v))
+	}
+	changes.dropped += int64(len(dropKeys))
+	changes.hashed += int64(len(hashKeys))
+}
+
+// hash returns the hex encoded SHA-256 hash of the value.
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,335 @@
+package tenantpolicyprocessor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// policyExtensions are the extensions of the policy files.
+var policyExtensions = []string{".yml", ".yaml"}
+
+// policyFileState is the state of a loaded policy file, a change triggers a reload.
+type policyFileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type processor struct {
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	policyDirectory      string
+	reloadInterval       time.Duration
+
+	// files holds the state of the policy files by path, it is only used by the loading goroutine.
+	files map[string]policyFileState
+
+	// mu guards the policies.
+	mu       sync.RWMutex
+	policies map[string]*policy
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+var _ processorhelper.MProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config) *processor {
+	return &processor{
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		policyDirectory:      cfg.PolicyDirectory,
+		reloadInterval:       cfg.ReloadInterval,
+		files:                map[string]policyFileState{},
+		policies:             map[string]*policy{},
+		done:                 make(chan struct{}),
+	}
+}
+
+// start loads the policies and watches the policy directory for changes.
+func (p *processor) start(context.Context, component.Host) error {
+	if err := p.reload(); err != nil {
+		return fmt.Errorf("failed to load policies from %q: %w", p.policyDirectory, err)
+	}
+	if p.reloadInterval == 0 {
+		return nil
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.reloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				if err := p.reload(); err != nil {
+					p.logger.Warn("Failed to check policy directory", zap.String("path", p.policyDirectory), zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *processor) shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	return nil
+}
+
+// reload loads the policy files which were added or changed and removes the
+// policies of deleted files. A policy which fails to load is logged and the
+// previous version is kept. The files are stat'ed through symlinks since
+// mounted ConfigMaps update the files by swapping a symlinked directory.
+func (p *processor) reload() error {
+	infos, err := ioutil.ReadDir(p.policyDirectory)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	// tenantPaths holds a present policy file of every tenant.
+	tenantPaths := map[string]string{}
+	for _, entry := range infos {
+		path := filepath.Join(p.policyDirectory, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			p.logger.Warn("Failed to check tenant policy", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		tenantID, ok := policyTenantID(info)
+		if !ok {
+			continue
+		}
+		seen[path] = true
+		tenantPaths[tenantID] = path
+		state := policyFileState{modTime: info.ModTime(), size: info.Size()}
+		if prev, ok := p.files[path]; ok && prev.modTime.Equal(state.modTime) && prev.size == state.size {
+			continue
+		}
+		p.files[path] = state
+		p.loadAndRecord(tenantID, path)
+	}
+
+	for path := range p.files {
+		if seen[path] {
+			continue
+		}
+		delete(p.files, path)
+		tenantID := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if other, ok := tenantPaths[tenantID]; ok {
+			// The tenant has both a .yml and a .yaml file, the remaining one applies.
+			p.loadAndRecord(tenantID, other)
+			continue
+		}
+		p.mu.Lock()
+		delete(p.policies, tenantID)
+		p.mu.Unlock()
+		p.logger.Info("Removed tenant policy", zap.String("path", path))
+	}
+	return nil
+}
+
+func (p *processor) loadAndRecord(tenantID, path string) {
+	result := resultSuccess
+	if err := p.load(tenantID, path); err != nil {
+		result = resultFailure
+		p.logger.Error("Failed to load tenant policy", zap.String("path", path), zap.Error(err))
+	} else {
+		p.logger.Info("Loaded tenant policy", zap.String("path", path))
+	}
+	ctx, _ := tag.New(context.Background(),
+		tag.Insert(tagTenantID, tenantID),
+		tag.Insert(tagResult, result))
+	stats.Record(ctx, statPolicyReloadCount.M(1))
+}
+
+// policyTenantID returns the tenant ID of a policy file.
+func policyTenantID(info os.FileInfo) (string, bool) {
+	if info.IsDir() {
+		return "", false
+	}
+	ext := filepath.Ext(info.Name())
+	for _, e := range policyExtensions {
+		if ext == e && len(info.Name()) > len(ext) {
+			return strings.TrimSuffix(info.Name(), ext), true
+		}
+	}
+	return "", false
+}
+
+func (p *processor) load(tenantID, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	pol, err := parsePolicy(data)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policies[tenantID] = pol
+	return nil
+}
+
+func (p *processor) policy(tenantID string) *policy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.policies[tenantID]
+}
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	dropped := map[string]int64{}
+	changes := map[string]*keyChanges{}
+
+	traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				pol := p.policy(tenantID)
+				if pol == nil {
+					return false
+				}
+				if pol.dropSpan(span.Name()) {
+					dropped[tenantID]++
+					return true
+				}
+				pol.applyAttributes(span.Attributes(), p.tenantIDAttributeKey, tenantChanges(changes, tenantID))
+				return false
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+
+	for tenantID, count := range dropped {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID))
+		stats.Record(tCtx, statDroppedSpanCount.M(count))
+	}
+	recordChanges(ctx, changes)
+
+	if traces.ResourceSpans().Len() == 0 {
+		return traces, processorhelper.ErrSkipProcessingData
+	}
+	return traces, nil
+}
+
+// ProcessMetrics implements processorhelper.MProcessor
+func (p *processor) ProcessMetrics(ctx context.Context, metrics pdata.Metrics) (pdata.Metrics, error) {
+	changes := map[string]*keyChanges{}
+
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceTenantID, _ := rm.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ms := ilms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				for _, labels := range labelMaps(ms.At(k)) {
+					tenantID := resourceTenantID.StringVal()
+					if label, ok := labels.Get(p.tenantIDAttributeKey); ok {
+						tenantID = label
+					}
+					if pol := p.policy(tenantID); pol != nil {
+						pol.applyLabels(labels, p.tenantIDAttributeKey, tenantChanges(changes, tenantID))
+					}
+				}
+			}
+		}
+	}
+
+	recordChanges(ctx, changes)
+	return metrics, nil
+}
+
+// labelMaps returns the labels of the data points of the metric.
+func labelMaps(metric pdata.Metric) []pdata.StringMap {
+	var labels []pdata.StringMap
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			labels = append(labels, dps.At(i).LabelsMap())
+		}
+	}
+	return labels
+}
+
+func tenantChanges(changes map[string]*keyChanges, tenantID string) *keyChanges {
+	c, ok := changes[tenantID]
+	if !ok {
+		c = &keyChanges{}
+		changes[tenantID] = c
+	}
+	return c
+}
+
+func recordChanges(ctx context.Context, changes map[string]*keyChanges) {
+	for tenantID, c := range changes {
+		for action, count := range map[string]int64{actionDrop: c.dropped, actionHash: c.hashed} {
+			if count == 0 {
+				continue
+			}
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagAction, action))
+			stats.Record(tCtx, statChangedKeyCount.M(count))
+		}
+	}
+}
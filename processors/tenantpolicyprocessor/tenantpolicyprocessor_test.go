@@ -0,0 +1,237 @@
+package tenantpolicyprocessor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor(t *testing.T, dir string) *processor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PolicyDirectory = dir
+	cfg.ReloadInterval = 0
+	p := newProcessor(zap.NewNop(), cfg)
+	require.NoError(t, p.start(context.Background(), nil))
+	return p
+}
+
+func newTestTraces(tenantID string, names ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, name := range names {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.Attributes().InsertString("http.method", "GET")
+		span.Attributes().InsertInt("http.status_code", 200)
+		span.Attributes().InsertString("http.url", "https://example.com/users/1")
+		span.Attributes().InsertString("enduser.id", "jdoe@example.com")
+	}
+	return td
+}
+
+func spanNames(td pdata.Traces) []string {
+	var names []string
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]string {
+	result := map[string]string{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		result[k] = v.StringVal()
+		return true
+	})
+	return result
+}
+
+func labelsAsMap(labels pdata.StringMap) map[string]string {
+	result := map[string]string{}
+	labels.Range(func(k string, v string) bool {
+		result[k] = v
+		return true
+	})
+	return result
+}
+
+func TestApplyPolicyToSpans(t *testing.T) {
+	p := newTestProcessor(t, "testdata/policies")
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces("jdoe", "GET /users", "GET /healthz"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /users"}, spanNames(td))
+
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, map[string]string{
+		"http.method": "GET",
+		"enduser.id":  hash("jdoe@example.com"),
+	}, attributesAsMap(span.Attributes()))
+	// Resource attributes are not changed.
+	assert.Equal(t, 1, td.ResourceSpans().At(0).Resource().Attributes().Len())
+}
+
+func TestTenantWithoutPolicy(t *testing.T) {
+	p := newTestProcessor(t, "testdata/policies")
+
+	td, err := p.ProcessTraces(context.Background(), newTestTraces("acme", "GET /healthz"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /healthz"}, spanNames(td))
+	assert.Equal(t, 4, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Len())
+}
+
+func TestAllSpansDropped(t *testing.T) {
+	p := newTestProcessor(t, "testdata/policies")
+
+	_, err := p.ProcessTraces(context.Background(), newTestTraces("jdoe", "GET /health"))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestApplyPolicyToMetricLabels(t *testing.T) {
+	p := newTestProcessor(t, "testdata/policies")
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	metric := metrics.AppendEmpty()
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	dp := metric.IntSum().DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert(defaultTenantIDAttributeKey, "jdoe")
+	dp.LabelsMap().Insert("http.method", "GET")
+	dp.LabelsMap().Insert("http.status_code", "200")
+	dp.LabelsMap().Insert("enduser.id", "jdoe@example.com")
+	dp.LabelsMap().Insert("host.name", "web-1")
+
+	metric = metrics.AppendEmpty()
+	metric.SetDataType(pdata.MetricDataTypeHistogram)
+	other := metric.Histogram().DataPoints().AppendEmpty()
+	other.LabelsMap().Insert(defaultTenantIDAttributeKey, "acme")
+	other.LabelsMap().Insert("host.name", "web-2")
+
+	md, err := p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		defaultTenantIDAttributeKey: "jdoe",
+		"http.method":               "GET",
+		"enduser.id":                hash("jdoe@example.com"),
+	}, labelsAsMap(dp.LabelsMap()))
+	assert.Equal(t, map[string]string{
+		defaultTenantIDAttributeKey: "acme",
+		"host.name":                 "web-2",
+	}, labelsAsMap(other.LabelsMap()))
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tenantpolicy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "jdoe.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("drop: [http.url]\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("drop: [http.method]\n"), 0600))
+
+	p := newTestProcessor(t, dir)
+	td, err := p.ProcessTraces(context.Background(), newTestTraces("jdoe", "GET /users"))
+	require.NoError(t, err)
+	attrs := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	assert.Equal(t, 3, attrs.Len())
+	_, ok := attrs.Get("http.url")
+	assert.False(t, ok)
+
+	// An invalid policy keeps the previous one.
+	require.NoError(t, ioutil.WriteFile(path, []byte("dorp: [http.method]\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	require.NoError(t, p.reload())
+	assert.NotNil(t, p.policy("jdoe"))
+	assert.True(t, p.policy("jdoe").drop["http.url"])
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("drop: [http.method]\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now().Add(2*time.Minute), time.Now().Add(2*time.Minute)))
+	require.NoError(t, p.reload())
+	assert.True(t, p.policy("jdoe").drop["http.method"])
+	assert.False(t, p.policy("jdoe").drop["http.url"])
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, p.reload())
+	assert.Nil(t, p.policy("jdoe"))
+}
+
+func TestReloadConfigMapVolume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tenantpolicy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A ConfigMap volume links the files to a data directory, an update
+	// writes a new data directory and swaps the ..data symlink.
+	writeData := func(name, content string, modTime time.Time) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0700))
+		path := filepath.Join(dir, name, "jdoe.yml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		require.NoError(t, os.Symlink(name, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	writeData("..2021_07_01", "drop: [http.url]\n", time.Now())
+	require.NoError(t, os.Symlink(filepath.Join("..data", "jdoe.yml"), filepath.Join(dir, "jdoe.yml")))
+
+	p := newTestProcessor(t, dir)
+	require.NotNil(t, p.policy("jdoe"))
+	assert.True(t, p.policy("jdoe").drop["http.url"])
+
+	// Same size, only the content and the time differ.
+	writeData("..2021_07_02", "drop: [http.uri]\n", time.Now().Add(time.Minute))
+	require.NoError(t, p.reload())
+	assert.True(t, p.policy("jdoe").drop["http.uri"])
+	assert.False(t, p.policy("jdoe").drop["http.url"])
+}
+
+func TestReloadBothExtensions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tenantpolicy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "jdoe.yaml"), []byte("drop: [http.url]\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "jdoe.yml"), []byte("drop: [http.method]\n"), 0600))
+
+	p := newTestProcessor(t, dir)
+	require.NotNil(t, p.policy("jdoe"))
+
+	// The policy of the remaining file applies.
+	require.NoError(t, os.Remove(filepath.Join(dir, "jdoe.yml")))
+	require.NoError(t, p.reload())
+	require.NotNil(t, p.policy("jdoe"))
+	assert.True(t, p.policy("jdoe").drop["http.url"])
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "jdoe.yaml")))
+	require.NoError(t, p.reload())
+	assert.Nil(t, p.policy("jdoe"))
+}
+
+func TestParsePolicy(t *testing.T) {
+	_, err := parsePolicy([]byte("drop_span_names: ['(']"))
+	assert.Error(t, err)
+
+	_, err = parsePolicy([]byte("keep: http.method"))
+	assert.Error(t, err)
+
+	pol, err := parsePolicy([]byte("drop_span_names: [GET /health]"))
+	require.NoError(t, err)
+	assert.True(t, pol.dropSpan("GET /health"))
+	assert.False(t, pol.dropSpan("GET /healthz"))
+}
@@ -0,0 +1,69 @@
+package tenantroutingprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for tenant routing processor.
+// The processor splits batches by tenant and sends every part to the exporters
+// of the route of the tenant, tenants without a route go to the default
+// exporters. The processor must be the last one of the pipeline and the
+// pipeline must list all the exporters used by the routes. Spans are routed by
+// their tenant, metrics by the tenant of their resource.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span and resource attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// DefaultExporters receive the data of tenants without a route.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+	// Routes map tenants to exporters.
+	Routes []RouteConfig `mapstructure:"routes"`
+}
+
+// RouteConfig defines the exporters of a group of tenants.
+type RouteConfig struct {
+	// Name identifies the route in the metrics.
+	Name string `mapstructure:"name"`
+	// Tenants are the IDs of the tenants taking the route.
+	Tenants []string `mapstructure:"tenants"`
+	// Exporters receive the data of the tenants.
+	Exporters []string `mapstructure:"exporters"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.DefaultExporters) == 0 {
+		return errors.New("default_exporters is required")
+	}
+
+	names := map[string]bool{defaultRouteName: true}
+	tenants := map[string]string{}
+	for _, r := range cfg.Routes {
+		if r.Name == "" {
+			return errors.New("route name is required")
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate route name %q", r.Name)
+		}
+		names[r.Name] = true
+		if len(r.Tenants) == 0 {
+			return fmt.Errorf("route %q has no tenants", r.Name)
+		}
+		if len(r.Exporters) == 0 {
+			return fmt.Errorf("route %q has no exporters", r.Name)
+		}
+		for _, tenantID := range r.Tenants {
+			if other, ok := tenants[tenantID]; ok {
+				return fmt.Errorf("tenant %q is in routes %q and %q", tenantID, other, r.Name)
+			}
+			tenants[tenantID] = r.Name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package tenantroutingprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfig(path.Join(".", "testdata", "config.yml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	rCfg := cfg.Processors[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, rCfg.TenantIDAttributeKey)
+	assert.Equal(t, []string{"nop"}, rCfg.DefaultExporters)
+	assert.Equal(t, []RouteConfig{
+		{Name: "premium", Tenants: []string{"jdoe", "acme"}, Exporters: []string{"nop/premium"}},
+	}, rCfg.Routes)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+
+	cfg.DefaultExporters = []string{"kafka/trial"}
+	assert.NoError(t, cfg.Validate())
+
+	tests := [][]RouteConfig{
+		{{Tenants: []string{"jdoe"}, Exporters: []string{"kafka"}}},
+		{{Name: "default", Tenants: []string{"jdoe"}, Exporters: []string{"kafka"}}},
+		{{Name: "premium", Exporters: []string{"kafka"}}},
+		{{Name: "premium", Tenants: []string{"jdoe"}}},
+		{
+			{Name: "premium", Tenants: []string{"jdoe"}, Exporters: []string{"kafka"}},
+			{Name: "premium", Tenants: []string{"acme"}, Exporters: []string{"kafka"}},
+		},
+		{
+			{Name: "premium", Tenants: []string{"jdoe"}, Exporters: []string{"kafka"}},
+			{Name: "trial", Tenants: []string{"jdoe"}, Exporters: []string{"kafka"}},
+		},
+	}
+	for _, routes := range tests {
+		cfg.Routes = routes
+		assert.Error(t, cfg.Validate(), routes)
+	}
+}
@@ -0,0 +1,54 @@
+package tenantroutingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_tenantrouting"
+	defaultTenantIDAttributeKey = "tenant-id"
+)
+
+// NewFactory creates a factory for the tenant routing processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+	}
+}
+
+// The next consumers are not used, the data is sent to the exporters of the routes.
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	_ consumer.Traces,
+) (component.TracesProcessor, error) {
+	return newProcessor(params.Logger, cfg.(*Config), config.TracesDataType)
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	_ consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	return newProcessor(params.Logger, cfg.(*Config), config.MetricsDataType)
+}
@@ -0,0 +1,49 @@
+package tenantroutingprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	tagRoute    = tag.MustNewKey("route")
+
+	statRoutedSpanCount    = stats.Int64("tenant_routing_routed_span_count", "Number of spans sent to the exporters of a route", stats.UnitDimensionless)
+	statRoutedMetricCount  = stats.Int64("tenant_routing_routed_metric_count", "Number of metrics sent to the exporters of a route", stats.UnitDimensionless)
+	statExportFailureCount = stats.Int64("tenant_routing_export_failure_count", "Number of batch parts an exporter of a route failed to consume", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for tenant routing processor.
+func MetricViews() []*view.View {
+	viewRoutedSpanCount := &view.View{
+		Name:        statRoutedSpanCount.Name(),
+		Description: statRoutedSpanCount.Description(),
+		Measure:     statRoutedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagRoute},
+	}
+
+	viewRoutedMetricCount := &view.View{
+		Name:        statRoutedMetricCount.Name(),
+		Description: statRoutedMetricCount.Description(),
+		Measure:     statRoutedMetricCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagRoute},
+	}
+
+	viewExportFailureCount := &view.View{
+		Name:        statExportFailureCount.Name(),
+		Description: statExportFailureCount.Description(),
+		Measure:     statExportFailureCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagRoute},
+	}
+
+	return []*view.View{
+		viewRoutedSpanCount,
+		viewRoutedMetricCount,
+		viewExportFailureCount,
+	}
+}
@@ -0,0 +1,303 @@
+package tenantroutingprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// defaultRouteName identifies the default route in the metrics.
+const defaultRouteName = "default"
+
+type route struct {
+	name        string
+	exporterIDs []config.ComponentID
+	// traces and metrics are the exporters resolved on start.
+	traces  []consumer.Traces
+	metrics []consumer.Metrics
+}
+
+type processor struct {
+	logger               *zap.Logger
+	tenantIDAttributeKey string
+	dataType             config.DataType
+	defaultRoute         *route
+	tenantRoutes         map[string]*route
+}
+
+var _ component.TracesProcessor = (*processor)(nil)
+var _ component.MetricsProcessor = (*processor)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config, dataType config.DataType) (*processor, error) {
+	defaultRoute, err := newRoute(defaultRouteName, cfg.DefaultExporters)
+	if err != nil {
+		return nil, err
+	}
+	tenantRoutes := map[string]*route{}
+	for _, r := range cfg.Routes {
+		tenantRoute, err := newRoute(r.Name, r.Exporters)
+		if err != nil {
+			return nil, err
+		}
+		for _, tenantID := range r.Tenants {
+			tenantRoutes[tenantID] = tenantRoute
+		}
+	}
+	return &processor{
+		logger:               logger,
+		tenantIDAttributeKey: cfg.TenantIDAttributeKey,
+		dataType:             dataType,
+		defaultRoute:         defaultRoute,
+		tenantRoutes:         tenantRoutes,
+	}, nil
+}
+
+func newRoute(name string, exporters []string) (*route, error) {
+	r := &route{name: name}
+	for _, exporter := range exporters {
+		id, err := config.NewIDFromString(exporter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exporter %q of route %q: %w", exporter, name, err)
+		}
+		r.exporterIDs = append(r.exporterIDs, id)
+	}
+	return r, nil
+}
+
+func (p *processor) routes() []*route {
+	routes := []*route{p.defaultRoute}
+	seen := map[*route]bool{p.defaultRoute: true}
+	for _, r := range p.tenantRoutes {
+		if !seen[r] {
+			seen[r] = true
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// Start resolves the exporters of the routes.
+func (p *processor) Start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()[p.dataType]
+	for _, r := range p.routes() {
+		r.traces, r.metrics = nil, nil
+		for _, id := range r.exporterIDs {
+			exporter, ok := exporters[id]
+			if !ok {
+				return fmt.Errorf("exporter %q of route %q is not configured for %s", id, r.name, p.dataType)
+			}
+			switch p.dataType {
+			case config.TracesDataType:
+				traces, ok := exporter.(consumer.Traces)
+				if !ok {
+					return fmt.Errorf("exporter %q of route %q does not consume traces", id, r.name)
+				}
+				r.traces = append(r.traces, traces)
+			case config.MetricsDataType:
+				metrics, ok := exporter.(consumer.Metrics)
+				if !ok {
+					return fmt.Errorf("exporter %q of route %q does not consume metrics", id, r.name)
+				}
+				r.metrics = append(r.metrics, metrics)
+			}
+		}
+	}
+	return nil
+}
+
+// Shutdown implements component.Component
+func (p *processor) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements consumer.Traces and consumer.Metrics
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *processor) route(tenantID string) *route {
+	if r, ok := p.tenantRoutes[tenantID]; ok {
+		return r
+	}
+	return p.defaultRoute
+}
+
+// routeCounts counts the routed items per route and tenant.
+type routeCounts map[*route]map[string]int64
+
+func (c routeCounts) inc(r *route, tenantID string, count int64) {
+	if _, ok := c[r]; !ok {
+		c[r] = map[string]int64{}
+	}
+	c[r][tenantID] += count
+}
+
+func (c routeCounts) record(ctx context.Context, measure *stats.Int64Measure) {
+	for r, tenants := range c {
+		for tenantID, count := range tenants {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagRoute, r.name))
+			stats.Record(tCtx, measure.M(count))
+		}
+	}
+}
+
+// ConsumeTraces splits the traces by the route of the span tenants and sends every part to the route exporters.
+func (p *processor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	counts := routeCounts{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				tenantID := p.spanTenantID(rs, spans.At(k))
+				counts.inc(p.route(tenantID), tenantID, 1)
+			}
+		}
+	}
+	counts.record(ctx, statRoutedSpanCount)
+
+	var parts map[*route]pdata.Traces
+	if len(counts) == 1 {
+		// No need to split when all the spans take the same route.
+		for r := range counts {
+			parts = map[*route]pdata.Traces{r: td}
+		}
+	} else {
+		parts = p.splitTraces(td)
+	}
+
+	var errs []error
+	for r, part := range parts {
+		for i, exporter := range r.traces {
+			if err := exporter.ConsumeTraces(ctx, part); err != nil {
+				errs = append(errs, p.exportFailure(ctx, r, i, err))
+			}
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (p *processor) spanTenantID(rs pdata.ResourceSpans, span pdata.Span) string {
+	if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+		return attr.StringVal()
+	}
+	return p.resourceTenantID(rs.Resource())
+}
+
+// routedTraces is the part of the traces taking a route. It holds the last
+// copied resource and instrumentation library, spans following in the same
+// source resource and library are appended to them.
+type routedTraces struct {
+	td       pdata.Traces
+	rs       pdata.ResourceSpans
+	ils      pdata.InstrumentationLibrarySpans
+	rsIndex  int
+	ilsIndex int
+}
+
+func (p *processor) splitTraces(td pdata.Traces) map[*route]pdata.Traces {
+	routed := map[*route]*routedTraces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				r := p.route(p.spanTenantID(rs, span))
+				part, ok := routed[r]
+				if !ok {
+					part = &routedTraces{td: pdata.NewTraces(), rsIndex: -1}
+					routed[r] = part
+				}
+				if part.rsIndex != i {
+					part.rs = part.td.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(part.rs.Resource())
+					part.rsIndex, part.ilsIndex = i, -1
+				}
+				if part.ilsIndex != j {
+					part.ils = part.rs.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(part.ils.InstrumentationLibrary())
+					part.ilsIndex = j
+				}
+				span.CopyTo(part.ils.Spans().AppendEmpty())
+			}
+		}
+	}
+
+	parts := make(map[*route]pdata.Traces, len(routed))
+	for r, part := range routed {
+		parts[r] = part.td
+	}
+	return parts
+}
+
+// ConsumeMetrics splits the metrics by the route of the resource tenants and sends every part to the route exporters.
+func (p *processor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	counts := routeCounts{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenantID := p.resourceTenantID(rm.Resource())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			counts.inc(p.route(tenantID), tenantID, int64(ilms.At(j).Metrics().Len()))
+		}
+	}
+	counts.record(ctx, statRoutedMetricCount)
+
+	parts := map[*route]pdata.Metrics{}
+	if len(counts) == 1 {
+		for r := range counts {
+			parts[r] = md
+		}
+	} else {
+		for i := 0; i < rms.Len(); i++ {
+			r := p.route(p.resourceTenantID(rms.At(i).Resource()))
+			if _, ok := parts[r]; !ok {
+				parts[r] = pdata.NewMetrics()
+			}
+			rms.At(i).CopyTo(parts[r].ResourceMetrics().AppendEmpty())
+		}
+	}
+
+	var errs []error
+	for r, part := range parts {
+		for i, exporter := range r.metrics {
+			if err := exporter.ConsumeMetrics(ctx, part); err != nil {
+				errs = append(errs, p.exportFailure(ctx, r, i, err))
+			}
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (p *processor) resourceTenantID(resource pdata.Resource) string {
+	attr, _ := resource.Attributes().Get(p.tenantIDAttributeKey)
+	return attr.StringVal()
+}
+
+func (p *processor) exportFailure(ctx context.Context, r *route, exporter int, err error) error {
+	p.logger.Debug("Failed to export routed data",
+		zap.String("route", r.name),
+		zap.Stringer("exporter", r.exporterIDs[exporter]),
+		zap.Error(err))
+	tCtx, _ := tag.New(ctx,
+		tag.Insert(tagRoute, r.name))
+	stats.Record(tCtx, statExportFailureCount.M(1))
+	return err
+}
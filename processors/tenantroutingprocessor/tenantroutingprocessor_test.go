@@ -0,0 +1,206 @@
+package tenantroutingprocessor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type nopComponent struct{}
+
+func (nopComponent) Start(context.Context, component.Host) error { return nil }
+func (nopComponent) Shutdown(context.Context) error              { return nil }
+
+type tracesExporter struct {
+	nopComponent
+	*consumertest.TracesSink
+}
+
+type failingExporter struct {
+	nopComponent
+	consumertest.Consumer
+}
+
+type metricsExporter struct {
+	nopComponent
+	*consumertest.MetricsSink
+}
+
+// testHost returns the given exporters.
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultExporters = []string{"kafka/trial"}
+	cfg.Routes = []RouteConfig{
+		{Name: "premium", Tenants: []string{"jdoe", "acme"}, Exporters: []string{"kafka/premium", "otlp"}},
+	}
+	return cfg
+}
+
+func newTracesHost() (*testHost, map[string]*consumertest.TracesSink) {
+	sinks := map[string]*consumertest.TracesSink{}
+	exporters := map[config.ComponentID]component.Exporter{}
+	for _, name := range []string{"kafka/trial", "kafka/premium", "otlp"} {
+		id, _ := config.NewIDFromString(name)
+		sinks[name] = new(consumertest.TracesSink)
+		exporters[id] = tracesExporter{TracesSink: sinks[name]}
+	}
+	return &testHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{config.TracesDataType: exporters},
+	}, sinks
+}
+
+func sinkSpanNames(sink *consumertest.TracesSink) []string {
+	var names []string
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					names = append(names, spans.At(k).Name())
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRouteTraces(t *testing.T) {
+	host, sinks := newTracesHost()
+	p, err := newProcessor(zap.NewNop(), newTestConfig(), config.TracesDataType)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "jdoe")
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	ils.InstrumentationLibrary().SetName("lib")
+	ils.Spans().AppendEmpty().SetName("jdoe-1")
+	span := ils.Spans().AppendEmpty()
+	span.SetName("trial-1")
+	span.Attributes().InsertString(defaultTenantIDAttributeKey, "trial")
+	ils.Spans().AppendEmpty().SetName("jdoe-2")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "acme")
+	rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("acme-1")
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, []string{"trial-1"}, sinkSpanNames(sinks["kafka/trial"]))
+	assert.Equal(t, []string{"acme-1", "jdoe-1", "jdoe-2"}, sinkSpanNames(sinks["kafka/premium"]))
+	assert.Equal(t, []string{"acme-1", "jdoe-1", "jdoe-2"}, sinkSpanNames(sinks["otlp"]))
+
+	// Spans of the same resource and library stay together.
+	premium := sinks["kafka/premium"].AllTraces()[0]
+	require.Equal(t, 2, premium.ResourceSpans().Len())
+	jdoe := premium.ResourceSpans().At(0)
+	service, _ := jdoe.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.StringVal())
+	require.Equal(t, 1, jdoe.InstrumentationLibrarySpans().Len())
+	assert.Equal(t, "lib", jdoe.InstrumentationLibrarySpans().At(0).InstrumentationLibrary().Name())
+	assert.Equal(t, 2, jdoe.InstrumentationLibrarySpans().At(0).Spans().Len())
+}
+
+func TestSingleRouteIsNotSplit(t *testing.T) {
+	host, sinks := newTracesHost()
+	p, err := newProcessor(zap.NewNop(), newTestConfig(), config.TracesDataType)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "unknown")
+	rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	require.Len(t, sinks["kafka/trial"].AllTraces(), 1)
+	assert.Equal(t, td, sinks["kafka/trial"].AllTraces()[0])
+	assert.Empty(t, sinks["kafka/premium"].AllTraces())
+}
+
+func TestExportFailure(t *testing.T) {
+	host, sinks := newTracesHost()
+	p, err := newProcessor(zap.NewNop(), newTestConfig(), config.TracesDataType)
+	require.NoError(t, err)
+	host.exporters[config.TracesDataType][config.NewIDWithName("kafka", "premium")] = failingExporter{
+		Consumer: consumertest.NewErr(errors.New("unavailable")),
+	}
+	require.NoError(t, p.Start(context.Background(), host))
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "acme")
+	rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	assert.Error(t, p.ConsumeTraces(context.Background(), td))
+	// The other exporters of the route still receive the data.
+	assert.Equal(t, []string{"span"}, sinkSpanNames(sinks["otlp"]))
+}
+
+func TestMissingExporter(t *testing.T) {
+	host, _ := newTracesHost()
+	cfg := newTestConfig()
+	cfg.Routes[0].Exporters = []string{"kafka/gold"}
+	p, err := newProcessor(zap.NewNop(), cfg, config.TracesDataType)
+	require.NoError(t, err)
+	assert.Error(t, p.Start(context.Background(), host))
+
+	p, err = newProcessor(zap.NewNop(), newTestConfig(), config.MetricsDataType)
+	require.NoError(t, err)
+	assert.Error(t, p.Start(context.Background(), host))
+}
+
+func TestRouteMetrics(t *testing.T) {
+	sinks := map[string]*consumertest.MetricsSink{}
+	exporters := map[config.ComponentID]component.Exporter{}
+	for _, name := range []string{"kafka/trial", "kafka/premium", "otlp"} {
+		id, _ := config.NewIDFromString(name)
+		sinks[name] = new(consumertest.MetricsSink)
+		exporters[id] = metricsExporter{MetricsSink: sinks[name]}
+	}
+	host := &testHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{config.MetricsDataType: exporters},
+	}
+
+	p, err := newProcessor(zap.NewNop(), newTestConfig(), config.MetricsDataType)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+
+	md := pdata.NewMetrics()
+	for _, tenantID := range []string{"jdoe", "trial", "acme"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString(defaultTenantIDAttributeKey, tenantID)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(tenantID)
+	}
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	assert.Equal(t, 1, sinks["kafka/trial"].MetricsCount())
+	assert.Equal(t, 2, sinks["kafka/premium"].MetricsCount())
+	assert.Equal(t, 2, sinks["otlp"].MetricsCount())
+}
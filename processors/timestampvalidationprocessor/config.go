@@ -0,0 +1,51 @@
+package timestampvalidationprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Action defines what happens to a span with timestamps outside of the valid window.
+type Action string
+
+const (
+	// Reject drops the span.
+	Reject Action = "reject"
+	// Clamp moves the span to the receive time keeping its duration.
+	Clamp Action = "clamp"
+)
+
+// Config defines config for timestamp validation processor.
+// The processor checks that span start and end timestamps are within
+// a window around the time the span was received, which filters out
+// zero, pre-1970 and far-future timestamps.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+	// MaxPast is how far before the receive time a timestamp can be. Default 72h.
+	MaxPast time.Duration `mapstructure:"max_past"`
+	// MaxFuture is how far after the receive time a timestamp can be. Default 1h.
+	MaxFuture time.Duration `mapstructure:"max_future"`
+	// Action is either reject or clamp. Default reject.
+	Action Action `mapstructure:"action"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxPast <= 0 {
+		return fmt.Errorf("max_past must be positive, got %s", cfg.MaxPast)
+	}
+	if cfg.MaxFuture < 0 {
+		return fmt.Errorf("max_future must not be negative, got %s", cfg.MaxFuture)
+	}
+	if cfg.Action != Reject && cfg.Action != Clamp {
+		return fmt.Errorf("unknown action %q, expected %q or %q", cfg.Action, Reject, Clamp)
+	}
+	return nil
+}
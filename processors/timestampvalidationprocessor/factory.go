@@ -0,0 +1,60 @@
+package timestampvalidationprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	typeStr                     = "hypertrace_timestampvalidation"
+	defaultTenantIDAttributeKey = "tenant-id"
+	defaultMaxPast              = 72 * time.Hour
+	defaultMaxFuture            = time.Hour
+	defaultAction               = Reject
+)
+
+// NewFactory creates a factory for the timestamp validation processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(
+			config.NewID(typeStr),
+		),
+		TenantIDAttributeKey: defaultTenantIDAttributeKey,
+		MaxPast:              defaultMaxPast,
+		MaxFuture:            defaultMaxFuture,
+		Action:               defaultAction,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	pCfg := cfg.(*Config)
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		&processor{
+			tenantIDAttributeKey: pCfg.TenantIDAttributeKey,
+			maxPast:              pCfg.MaxPast,
+			maxFuture:            pCfg.MaxFuture,
+			action:               pCfg.Action,
+			now:                  time.Now,
+		})
+}
@@ -0,0 +1,16 @@
+package timestampvalidationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultTenantIDAttributeKey, cfg.TenantIDAttributeKey)
+	assert.Equal(t, defaultMaxPast, cfg.MaxPast)
+	assert.Equal(t, defaultMaxFuture, cfg.MaxFuture)
+	assert.Equal(t, defaultAction, cfg.Action)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,33 @@
+package timestampvalidationprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID = tag.MustNewKey("tenant-id")
+	// tagReceiver is set in the context by the receivers.
+	tagReceiver = tag.MustNewKey("receiver")
+	tagAction   = tag.MustNewKey("action")
+
+	statInvalidSpanPerTenant = stats.Int64("timestamp_validation_invalid_span_count", "Number of spans from a tenant with timestamps outside of the valid window", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for timestamp validation processor.
+func MetricViews() []*view.View {
+	tags := []tag.Key{tagTenantID, tagReceiver, tagAction}
+
+	viewInvalidSpanCount := &view.View{
+		Name:        statInvalidSpanPerTenant.Name(),
+		Description: statInvalidSpanPerTenant.Description(),
+		Measure:     statInvalidSpanPerTenant,
+		Aggregation: view.Sum(),
+		TagKeys:     tags,
+	}
+
+	return []*view.View{
+		viewInvalidSpanCount,
+	}
+}
@@ -0,0 +1,101 @@
+package timestampvalidationprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// clampedAttributeKey is set on spans moved to the receive time.
+const clampedAttributeKey = "timestamp.clamped"
+
+type processor struct {
+	tenantIDAttributeKey string
+	maxPast              time.Duration
+	maxFuture            time.Duration
+	action               Action
+	now                  func() time.Time
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	receiveTime := p.now()
+	minTimestamp := pdata.TimestampFromTime(receiveTime.Add(-p.maxPast))
+	maxTimestamp := pdata.TimestampFromTime(receiveTime.Add(p.maxFuture))
+	invalid := map[string]int64{}
+
+	traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				if inWindow(span.StartTimestamp(), minTimestamp, maxTimestamp) &&
+					inWindow(span.EndTimestamp(), minTimestamp, maxTimestamp) {
+					return false
+				}
+
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				invalid[tenantID]++
+
+				if p.action == Reject {
+					return true
+				}
+				clamp(span, pdata.TimestampFromTime(receiveTime), p.maxPast+p.maxFuture)
+				return false
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+
+	for tenantID, count := range invalid {
+		tCtx, _ := tag.New(ctx,
+			tag.Insert(tagTenantID, tenantID),
+			tag.Insert(tagAction, string(p.action)))
+		stats.Record(tCtx, statInvalidSpanPerTenant.M(count))
+	}
+
+	if traces.ResourceSpans().Len() == 0 {
+		return traces, processorhelper.ErrSkipProcessingData
+	}
+	return traces, nil
+}
+
+func inWindow(ts, min, max pdata.Timestamp) bool {
+	return ts >= min && ts <= max
+}
+
+// clamp moves the span to end at the receive time. The duration is kept
+// when it is not negative and fits in the valid window, otherwise it is reset to zero.
+func clamp(span pdata.Span, receiveTime pdata.Timestamp, window time.Duration) {
+	start, end := span.StartTimestamp(), span.EndTimestamp()
+	duration := pdata.Timestamp(0)
+	if end >= start && start > 0 && uint64(end-start) <= uint64(window) {
+		duration = end - start
+	}
+
+	newStart := receiveTime - duration
+	shift := int64(newStart) - int64(start)
+	span.SetStartTimestamp(newStart)
+	span.SetEndTimestamp(receiveTime)
+
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		ts := pdata.Timestamp(int64(event.Timestamp()) + shift)
+		if ts < newStart || ts > receiveTime {
+			ts = newStart
+		}
+		event.SetTimestamp(ts)
+	}
+
+	span.Attributes().UpsertBool(clampedAttributeKey, true)
+}
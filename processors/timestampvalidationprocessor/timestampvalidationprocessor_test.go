@@ -0,0 +1,117 @@
+package timestampvalidationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var testReceiveTime = time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestProcessor(action Action) *processor {
+	return &processor{
+		tenantIDAttributeKey: defaultTenantIDAttributeKey,
+		maxPast:              time.Hour,
+		maxFuture:            time.Minute,
+		action:               action,
+		now:                  func() time.Time { return testReceiveTime },
+	}
+}
+
+func generateTraces(timestamps ...[2]time.Time) pdata.Traces {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i, ts := range timestamps {
+		span := spans.AppendEmpty()
+		span.SetSpanID(pdata.NewSpanID([8]byte{byte(i + 1)}))
+		span.SetStartTimestamp(pdata.TimestampFromTime(ts[0]))
+		span.SetEndTimestamp(pdata.TimestampFromTime(ts[1]))
+	}
+	return td
+}
+
+func TestRejectInvalidTimestamps(t *testing.T) {
+	valid := [2]time.Time{testReceiveTime.Add(-time.Second), testReceiveTime}
+	td := generateTraces(
+		valid,
+		[2]time.Time{time.Unix(0, 0), time.Unix(0, 0)},
+		[2]time.Time{testReceiveTime, testReceiveTime.Add(24 * time.Hour)},
+		[2]time.Time{time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), testReceiveTime},
+	)
+
+	td, err := newTestProcessor(Reject).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewSpanID([8]byte{1}), span.SpanID())
+}
+
+func TestRejectAllSpans(t *testing.T) {
+	td := generateTraces([2]time.Time{time.Unix(0, 0), time.Unix(0, 0)})
+	_, err := newTestProcessor(Reject).ProcessTraces(context.Background(), td)
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func TestClampKeepsDuration(t *testing.T) {
+	start := testReceiveTime.Add(48 * time.Hour)
+	td := generateTraces([2]time.Time{start, start.Add(time.Second)})
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Events().AppendEmpty().SetTimestamp(pdata.TimestampFromTime(start.Add(500 * time.Millisecond)))
+
+	td, err := newTestProcessor(Clamp).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+
+	assert.Equal(t, pdata.TimestampFromTime(testReceiveTime.Add(-time.Second)), span.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(testReceiveTime), span.EndTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(testReceiveTime.Add(-500*time.Millisecond)), span.Events().At(0).Timestamp())
+	clamped, ok := span.Attributes().Get(clampedAttributeKey)
+	require.True(t, ok)
+	assert.True(t, clamped.BoolVal())
+}
+
+func TestClampZeroTimestamp(t *testing.T) {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetEndTimestamp(pdata.TimestampFromTime(testReceiveTime))
+
+	_, err := newTestProcessor(Clamp).ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, pdata.TimestampFromTime(testReceiveTime), span.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(testReceiveTime), span.EndTimestamp())
+}
+
+func TestMetricViewsTags(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	td := generateTraces(
+		[2]time.Time{testReceiveTime, testReceiveTime},
+		[2]time.Time{time.Unix(0, 0), time.Unix(0, 0)},
+		[2]time.Time{testReceiveTime, testReceiveTime.Add(24 * time.Hour)},
+	)
+	td.ResourceSpans().At(0).Resource().Attributes().InsertString(defaultTenantIDAttributeKey, "jdoe")
+	ctx := obsreport.ReceiverContext(context.Background(), config.NewID("jaeger"), "grpc")
+	_, err := newTestProcessor(Reject).ProcessTraces(ctx, td)
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(statInvalidSpanPerTenant.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t, []tag.Tag{
+		{Key: tagTenantID, Value: "jdoe"},
+		{Key: tagReceiver, Value: "jaeger"},
+		{Key: tagAction, Value: string(Reject)},
+	}, rows[0].Tags)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+}
@@ -0,0 +1,18 @@
+package useragentprocessor
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines config for user agent processor.
+// The processor parses the http.user_agent attribute with the rules compiled
+// into the collector and adds the browser, operating system, device type
+// and bot flag as user_agent.* attributes.
+type Config struct {
+	config.ProcessorSettings `mapstructure:"-"`
+
+	// TenantIDAttributeKey defines span attribute key for tenant. Default tenant-id.
+	TenantIDAttributeKey string `mapstructure:"tenant_id_attribute_key"`
+}
+
+var _ config.Processor = (*Config)(nil)
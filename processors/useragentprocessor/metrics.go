@@ -0,0 +1,29 @@
+package useragentprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagTenantID   = tag.MustNewKey("tenant-id")
+	tagDeviceType = tag.MustNewKey("device-type")
+
+	statParsedSpanCount = stats.Int64("user_agent_parsed_span_count", "Number of spans with a parsed user agent", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views for user agent processor.
+func MetricViews() []*view.View {
+	viewParsedSpanCount := &view.View{
+		Name:        statParsedSpanCount.Name(),
+		Description: statParsedSpanCount.Description(),
+		Measure:     statParsedSpanCount,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagTenantID, tagDeviceType},
+	}
+
+	return []*view.View{
+		viewParsedSpanCount,
+	}
+}
@@ -0,0 +1,81 @@
+package useragentprocessor
+
+import (
+	"regexp"
+)
+
+// rule matches a product in the user agent. The first submatch, if any, is the version.
+type rule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+func newRule(name, pattern string) rule {
+	return rule{name: name, re: regexp.MustCompile(pattern)}
+}
+
+// The rules are evaluated in order and the first match wins, more specific
+// products have to be listed before the ones they derive from, e.g. Edge before Chrome.
+
+var botRules = []rule{
+	newRule("Googlebot", `(?i)googlebot(?:-\w+)?/?([\d.]*)`),
+	newRule("Bingbot", `(?i)bingbot/([\d.]+)`),
+	newRule("YandexBot", `(?i)yandex(?:bot|images)/([\d.]+)`),
+	newRule("Baiduspider", `(?i)baiduspider(?:-\w+)?/?([\d.]*)`),
+	newRule("DuckDuckBot", `(?i)duckduckbot(?:-https)?/([\d.]+)`),
+	newRule("Yahoo! Slurp", `(?i)yahoo! slurp`),
+	newRule("Applebot", `(?i)applebot/([\d.]+)`),
+	newRule("facebookexternalhit", `(?i)facebookexternalhit/([\d.]+)`),
+	newRule("Twitterbot", `(?i)twitterbot/([\d.]+)`),
+	newRule("LinkedInBot", `(?i)linkedinbot/([\d.]+)`),
+	newRule("AhrefsBot", `(?i)ahrefsbot/([\d.]+)`),
+	newRule("SemrushBot", `(?i)semrushbot/?([\d.~]*)`),
+	newRule("HeadlessChrome", `HeadlessChrome/([\d.]+)`),
+	newRule("curl", `^curl/([\d.]+)`),
+	newRule("Wget", `^Wget/([\d.]+)`),
+	newRule("Python Requests", `^python-requests/([\d.]+)`),
+	newRule("Python urllib", `^Python-urllib/([\d.]+)`),
+	newRule("Go HTTP client", `^Go-http-client/([\d.]+)`),
+	newRule("Apache HttpClient", `^Apache-HttpClient/([\d.]+)`),
+	newRule("Java", `^Java/([\d._]+)`),
+	newRule("Postman", `^PostmanRuntime/([\d.]+)`),
+	newRule("Other Bot", `(?i)(?:bot|crawler|spider|crawling|scraper)\b`),
+}
+
+var browserRules = []rule{
+	newRule("Edge", `(?:Edg|Edge|EdgA|EdgiOS)/([\d.]+)`),
+	newRule("Opera", `(?:OPR|OPiOS|Opera)/([\d.]+)`),
+	newRule("Samsung Internet", `SamsungBrowser/([\d.]+)`),
+	newRule("UC Browser", `UC ?Browser/([\d.]+)`),
+	newRule("Yandex Browser", `YaBrowser/([\d.]+)`),
+	newRule("Vivaldi", `Vivaldi/([\d.]+)`),
+	newRule("Firefox", `(?:Firefox|FxiOS)/([\d.]+)`),
+	newRule("Chrome", `(?:Chrome|CriOS)/([\d.]+)`),
+	newRule("Safari", `Version/([\d.]+).*Safari/`),
+	newRule("Internet Explorer", `(?:MSIE |Trident/.*rv:)([\d.]+)`),
+}
+
+var osRules = []rule{
+	newRule("Windows Phone", `Windows Phone(?: OS)? ([\d.]+)`),
+	newRule("Windows", `Windows NT ([\d.]+)`),
+	newRule("iOS", `(?:iPhone|CPU) OS ([\d_]+)`),
+	newRule("Mac OS X", `Mac OS X ?([\d_.]*)`),
+	newRule("Android", `Android ?([\d.]*)`),
+	newRule("Chrome OS", `CrOS \w+ ([\d.]+)`),
+	newRule("Linux", `Linux`),
+}
+
+// windowsVersions maps Windows NT versions to product versions.
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+	"6.0":  "Vista",
+	"5.1":  "XP",
+}
+
+var (
+	tabletRe = regexp.MustCompile(`(?i)iPad|Tablet|Kindle|Silk/|PlayBook`)
+	mobileRe = regexp.MustCompile(`(?i)Mobi|iPhone|iPod|Windows Phone|BlackBerry|Opera Mini`)
+)
@@ -0,0 +1,70 @@
+package useragentprocessor
+
+import (
+	"strings"
+)
+
+// Device types.
+const (
+	deviceBot     = "bot"
+	deviceTablet  = "tablet"
+	deviceMobile  = "mobile"
+	deviceDesktop = "desktop"
+	deviceOther   = "other"
+)
+
+type userAgent struct {
+	browser        string
+	browserVersion string
+	os             string
+	osVersion      string
+	device         string
+	bot            bool
+}
+
+// parse extracts the browser or bot, the operating system and the device type from ua.
+func parse(ua string) userAgent {
+	var result userAgent
+	if name, version, ok := match(botRules, ua); ok {
+		result.browser, result.browserVersion = name, version
+		result.bot = true
+	} else {
+		result.browser, result.browserVersion, _ = match(browserRules, ua)
+	}
+
+	result.os, result.osVersion, _ = match(osRules, ua)
+	result.osVersion = strings.ReplaceAll(result.osVersion, "_", ".")
+	if result.os == "Windows" {
+		if version, ok := windowsVersions[result.osVersion]; ok {
+			result.osVersion = version
+		}
+	}
+
+	switch {
+	case result.bot:
+		result.device = deviceBot
+	case tabletRe.MatchString(ua), result.os == "Android" && !strings.Contains(ua, "Mobile"):
+		result.device = deviceTablet
+	case mobileRe.MatchString(ua):
+		result.device = deviceMobile
+	case result.os != "":
+		result.device = deviceDesktop
+	default:
+		result.device = deviceOther
+	}
+	return result
+}
+
+// match returns the name and version of the first matching rule.
+func match(rules []rule, ua string) (string, string, bool) {
+	for _, r := range rules {
+		if m := r.re.FindStringSubmatch(ua); m != nil {
+			version := ""
+			if len(m) > 1 {
+				version = m[1]
+			}
+			return r.name, version, true
+		}
+	}
+	return "", "", false
+}
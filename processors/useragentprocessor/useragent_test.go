@@ -0,0 +1,72 @@
+package useragentprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		ua       string
+		expected userAgent
+	}{
+		{
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+			expected: userAgent{browser: "Chrome", browserVersion: "91.0.4472.124", os: "Windows", osVersion: "10", device: deviceDesktop},
+		},
+		{
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36 Edg/91.0.864.59",
+			expected: userAgent{browser: "Edge", browserVersion: "91.0.864.59", os: "Windows", osVersion: "10", device: deviceDesktop},
+		},
+		{
+			ua:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Safari/605.1.15",
+			expected: userAgent{browser: "Safari", browserVersion: "14.1.1", os: "Mac OS X", osVersion: "10.15.7", device: deviceDesktop},
+		},
+		{
+			ua:       "Mozilla/5.0 (iPhone; CPU iPhone OS 14_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Mobile/15E148 Safari/604.1",
+			expected: userAgent{browser: "Safari", browserVersion: "14.1.1", os: "iOS", osVersion: "14.6", device: deviceMobile},
+		},
+		{
+			ua:       "Mozilla/5.0 (iPad; CPU OS 14_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/91.0.4472.80 Mobile/15E148 Safari/604.1",
+			expected: userAgent{browser: "Chrome", browserVersion: "91.0.4472.80", os: "iOS", osVersion: "14.6", device: deviceTablet},
+		},
+		{
+			ua:       "Mozilla/5.0 (Linux; Android 11; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/14.2 Chrome/87.0.4280.141 Mobile Safari/537.36",
+			expected: userAgent{browser: "Samsung Internet", browserVersion: "14.2", os: "Android", osVersion: "11", device: deviceMobile},
+		},
+		{
+			ua:       "Mozilla/5.0 (Linux; Android 9; SM-T820) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.120 Safari/537.36",
+			expected: userAgent{browser: "Chrome", browserVersion: "91.0.4472.120", os: "Android", osVersion: "9", device: deviceTablet},
+		},
+		{
+			ua:       "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:89.0) Gecko/20100101 Firefox/89.0",
+			expected: userAgent{browser: "Firefox", browserVersion: "89.0", os: "Linux", device: deviceDesktop},
+		},
+		{
+			ua:       "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			expected: userAgent{browser: "Internet Explorer", browserVersion: "11.0", os: "Windows", osVersion: "7", device: deviceDesktop},
+		},
+		{
+			ua:       "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: userAgent{browser: "Googlebot", browserVersion: "2.1", device: deviceBot, bot: true},
+		},
+		{
+			ua:       "curl/7.64.1",
+			expected: userAgent{browser: "curl", browserVersion: "7.64.1", device: deviceBot, bot: true},
+		},
+		{
+			ua:       "Mozilla/5.0 (compatible; SomeNewCrawler; +https://example.com/crawler)",
+			expected: userAgent{browser: "Other Bot", device: deviceBot, bot: true},
+		},
+		{
+			ua:       "okhttp/4.9.0",
+			expected: userAgent{device: deviceOther},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ua, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parse(tt.ua))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package useragentprocessor
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// Attributes added to spans with a user agent.
+const (
+	browserNameAttributeKey    = "user_agent.browser.name"
+	browserVersionAttributeKey = "user_agent.browser.version"
+	osNameAttributeKey         = "user_agent.os.name"
+	osVersionAttributeKey      = "user_agent.os.version"
+	deviceTypeAttributeKey     = "user_agent.device.type"
+	isBotAttributeKey          = "user_agent.is_bot"
+)
+
+type processor struct {
+	tenantIDAttributeKey string
+}
+
+var _ processorhelper.TProcessor = (*processor)(nil)
+
+// ProcessTraces implements processorhelper.TProcessor
+func (p *processor) ProcessTraces(ctx context.Context, traces pdata.Traces) (pdata.Traces, error) {
+	parsed := map[string]map[string]int64{}
+
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceTenantID, _ := rs.Resource().Attributes().Get(p.tenantIDAttributeKey)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				attr, ok := span.Attributes().Get(conventions.AttributeHTTPUserAgent)
+				if !ok || attr.StringVal() == "" {
+					continue
+				}
+
+				ua := parse(attr.StringVal())
+				setAttributes(span.Attributes(), ua)
+
+				tenantID := resourceTenantID.StringVal()
+				if attr, ok := span.Attributes().Get(p.tenantIDAttributeKey); ok {
+					tenantID = attr.StringVal()
+				}
+				if _, ok := parsed[tenantID]; !ok {
+					parsed[tenantID] = map[string]int64{}
+				}
+				parsed[tenantID][ua.device]++
+			}
+		}
+	}
+
+	for tenantID, devices := range parsed {
+		for device, count := range devices {
+			tCtx, _ := tag.New(ctx,
+				tag.Insert(tagTenantID, tenantID),
+				tag.Insert(tagDeviceType, device))
+			stats.Record(tCtx, statParsedSpanCount.M(count))
+		}
+	}
+
+	return traces, nil
+}
+
+func setAttributes(attrs pdata.AttributeMap, ua userAgent) {
+	upsertNonEmpty(attrs, browserNameAttributeKey, ua.browser)
+	upsertNonEmpty(attrs, browserVersionAttributeKey, ua.browserVersion)
+	upsertNonEmpty(attrs, osNameAttributeKey, ua.os)
+	upsertNonEmpty(attrs, osVersionAttributeKey, ua.osVersion)
+	attrs.UpsertString(deviceTypeAttributeKey, ua.device)
+	attrs.UpsertBool(isBotAttributeKey, ua.bot)
+}
+
+func upsertNonEmpty(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.UpsertString(key, value)
+	}
+}
@@ -0,0 +1,55 @@
+package useragentprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestProcessTraces(t *testing.T) {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().InsertString("http.user_agent",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Safari/605.1.15")
+	spans.AppendEmpty().Attributes().InsertString("http.user_agent", "Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)")
+	spans.AppendEmpty().Attributes().InsertString("http.method", "GET")
+
+	p := &processor{tenantIDAttributeKey: defaultTenantIDAttributeKey}
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	spans = td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	assertAttributes(t, spans.At(0).Attributes(), map[string]interface{}{
+		browserNameAttributeKey:    "Safari",
+		browserVersionAttributeKey: "14.1.1",
+		osNameAttributeKey:         "Mac OS X",
+		osVersionAttributeKey:      "10.15.7",
+		deviceTypeAttributeKey:     deviceDesktop,
+		isBotAttributeKey:          false,
+	})
+	assertAttributes(t, spans.At(1).Attributes(), map[string]interface{}{
+		browserNameAttributeKey:    "Bingbot",
+		browserVersionAttributeKey: "2.0",
+		deviceTypeAttributeKey:     deviceBot,
+		isBotAttributeKey:          true,
+	})
+	assert.Equal(t, 1, spans.At(2).Attributes().Len())
+}
+
+func assertAttributes(t *testing.T, attrs pdata.AttributeMap, expected map[string]interface{}) {
+	for key, value := range expected {
+		attr, ok := attrs.Get(key)
+		require.True(t, ok, key)
+		if b, ok := value.(bool); ok {
+			assert.Equal(t, b, attr.BoolVal(), key)
+		} else {
+			assert.Equal(t, value, attr.StringVal(), key)
+		}
+	}
+	_, ok := attrs.Get(osNameAttributeKey)
+	_, expectOS := expected[osNameAttributeKey]
+	assert.Equal(t, expectOS, ok)
+}